@@ -16,11 +16,16 @@
 
 package smartsheet
 
-import "time"
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
 
 type Attachment struct {
-	Id                 int       // Attachment Id
-	ParentId           int       // The Id of the parent
+	Id                 int64     // Attachment Id
+	ParentId           int64     // The Id of the parent
 	AttachmentType     string    // Attachment type (one of BOX_COM, DROPBOX*, EGNYTE*, EVERNOTE*, FILE, GOOGLE_DRIVE, LINK, or ONEDRIVE) *Not supported for all account types, see below.
 	AttachmentSubType  string    // Attachment sub type, valid only for the following:
 	MimeType           string    // Attachment MIME type (PNG, etc.)
@@ -32,3 +37,49 @@ type Attachment struct {
 	Url                string    // Attachment temporary URL (files only)
 	UrlExpiresInMillis int       // Attachment temporary URL time to live (files only)
 }
+
+// AttachmentPage is a page of attachments returned by ListAttachments
+type AttachmentPage struct {
+	PageInfo
+	Data []Attachment `json:"data"`
+}
+
+// Return AttachmentPage object with the attachments of the sheet and of its rows and comments
+func (c Client) ListAttachments(sheetId int64, options *PageOptions) (*AttachmentPage, error) {
+	var page AttachmentPage
+	query := url.Values{}
+	options.setQuery(query)
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/sheets/%d/attachments", apiEndpoint, sheetId), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &page); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &page, nil
+}
+
+// Return Attachment object. For files, Url is a temporary download URL.
+func (c Client) GetAttachment(sheetId int64, attachmentId int64) (*Attachment, error) {
+	var attachment Attachment
+	resp, err := c.get(fmt.Sprintf("%s/sheets/%d/attachments/%d", apiEndpoint, sheetId, attachmentId))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &attachment); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &attachment, nil
+}
+
+// Return a reader streaming the bytes of a file attachment. The caller must close it.
+func (c Client) OpenAttachment(sheetId int64, attachmentId int64) (io.ReadCloser, error) {
+	attachment, err := c.GetAttachment(sheetId, attachmentId)
+	if err != nil {
+		return nil, err
+	}
+	if attachment.Url == "" {
+		return nil, fmt.Errorf("attachment %d is a %s attachment, not a file", attachmentId, attachment.AttachmentType)
+	}
+	return c.download(attachment.Url, fmt.Sprintf("attachment %d", attachmentId))
+}
@@ -0,0 +1,533 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	backupManifestFile = "manifest.json"
+	// Largest page of rows the API returns for a report
+	backupReportPageSize = 10000
+)
+
+// BackupManifest lists the contents of a workspace backup
+type BackupManifest struct {
+	WorkspaceId   int64        `json:"workspaceId"`   // Id of the backed up workspace
+	WorkspaceName string       `json:"workspaceName"` // Name of the backed up workspace
+	CreatedAt     time.Time    `json:"createdAt"`     // Time the backup started
+	Attachments   bool         `json:"attachments"`   // The bytes of file attachments were saved
+	Items         []BackupItem `json:"items"`         // Every folder, sheet, report, Sight and template in the workspace
+}
+
+// BackupItem is an item of a backed up workspace
+type BackupItem struct {
+	Type        ItemType `json:"type"`                  // Kind of the item
+	Id          int64    `json:"id"`                    // Id of the item
+	Name        string   `json:"name"`                  // Name of the item
+	Path        string   `json:"path"`                  // Path of the item within the workspace
+	ParentId    int64    `json:"parentId,omitempty"`    // Id of the folder holding the item. Zero at the top of the workspace
+	Version     int      `json:"version,omitempty"`     // Sheet version
+	File        string   `json:"file,omitempty"`        // Backup file holding the item's JSON, for sheets, reports and Sights
+	Attachments []string `json:"attachments,omitempty"` // Backup files holding the bytes of the sheet's file attachments
+	Unchanged   bool     `json:"unchanged,omitempty"`   // The sheet was skipped because its version matched the previous backup
+}
+
+// Backup copies a workspace to a local directory or tar.gz archive. Sheets are saved with their
+// columns, rows, formats, object values, discussions, cross-sheet references and attachment
+// metadata, reports with all of their rows, and Sights with their definitions. Files are the
+// indented API responses, so backups can be compared with diff.
+type Backup struct {
+	Client      Client
+	WorkspaceId int64
+	Attachments bool // Also save the bytes of file attachments
+	// Manifest of an earlier backup. Sheets whose version has not changed since are skipped,
+	// unless the earlier backup was made with a different Attachments setting.
+	Previous *BackupManifest
+	// Directory or archive of the earlier backup. The files of unchanged sheets are copied from it,
+	// unless the backup is written to the same directory. Required for incremental archives.
+	PreviousPath string
+}
+
+// backupTarget receives the files of a backup. size is -1 when it is not known in advance.
+type backupTarget interface {
+	write(name string, size int64, r io.Reader) error
+}
+
+// Return Backup of a workspace
+func (c Client) NewBackup(workspaceId int64) *Backup {
+	return &Backup{Client: c, WorkspaceId: workspaceId}
+}
+
+// Write the backup to a directory, creating it if needed. An incremental backup into the directory
+// of the previous backup, which is assumed when PreviousPath is empty, leaves the files of unchanged
+// sheets in place and removes the files of items deleted since.
+func (b *Backup) ToDirectory(dir string) (*BackupManifest, error) {
+	inPlace := b.Previous != nil && (b.PreviousPath == "" || filepath.Clean(b.PreviousPath) == filepath.Clean(dir))
+	manifest, err := b.run(dirTarget(dir), !inPlace)
+	if err != nil {
+		return nil, err
+	}
+	if inPlace {
+		if err := removeStaleFiles(dir, b.Previous, manifest); err != nil {
+			return nil, err
+		}
+	}
+	return manifest, nil
+}
+
+// Write the backup to a tar.gz archive. The files of unchanged sheets of an incremental backup
+// are copied from the archive or directory at PreviousPath, so every archive is complete.
+func (b *Backup) ToArchive(path string) (*BackupManifest, error) {
+	if b.Previous != nil && b.PreviousPath == "" {
+		return nil, fmt.Errorf("incremental backup to an archive needs the PreviousPath of the earlier backup")
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	gz := gzip.NewWriter(f)
+	tw := &tarTarget{tar.NewWriter(gz)}
+	manifest, err := b.run(tw, true)
+	for _, closer := range []io.Closer{tw.w, gz, f} {
+		if cErr := closer.Close(); cErr != nil && err == nil {
+			err = cErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// Return the manifest of a backup directory or tar.gz archive
+func ReadBackupManifest(path string) (*BackupManifest, error) {
+	data, err := readBackupFile(path, backupManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	var manifest BackupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("could not decode backup manifest: %v", err)
+	}
+	return &manifest, nil
+}
+
+// Back up the workspace into target. With copyUnchanged, the files of unchanged sheets are
+// copied from PreviousPath.
+func (b *Backup) run(target backupTarget, copyUnchanged bool) (*BackupManifest, error) {
+	workspace, err := b.Client.GetWorkspace(b.WorkspaceId, &GetWorkspaceOptions{LoadAll: true, Include: []string{"sheetVersion"}})
+	if err != nil {
+		return nil, err
+	}
+	manifest := &BackupManifest{WorkspaceId: workspace.Id, WorkspaceName: workspace.Name, CreatedAt: time.Now().UTC(), Attachments: b.Attachments}
+	previous := map[int64]BackupItem{}
+	if b.Previous != nil && b.Previous.WorkspaceId == workspace.Id && b.Previous.Attachments == b.Attachments {
+		for _, item := range b.Previous.Items {
+			if item.Type == ItemSheet {
+				previous[item.Id] = item
+			}
+		}
+	}
+	var unchanged []string
+	for _, item := range loadedItems("", 0, workspace.Sheets, workspace.Reports, workspace.Sights, workspace.Templates, workspace.Folders) {
+		entry := BackupItem{Type: item.Type, Id: item.Id, Name: item.Name, Path: item.Path, ParentId: item.parentId}
+		switch item.Type {
+		case ItemSheet:
+			entry.Version = item.Sheet.Version
+			entry.File = fmt.Sprintf("sheets/%d.json", item.Id)
+			if last, ok := previous[item.Id]; ok && entry.Version != 0 && last.Version == entry.Version {
+				entry.Attachments = last.Attachments
+				entry.Unchanged = true
+				unchanged = append(append(unchanged, entry.File), entry.Attachments...)
+				break
+			}
+			err = b.saveJSON(target, entry.File, fmt.Sprintf("%s/sheets/%d?include=attachments,crossSheetReferences,discussions,filterDefinitions,format,objectValue,ownerInfo,source&level=3", apiEndpoint, item.Id))
+			if err == nil && b.Attachments {
+				entry.Attachments, err = b.saveAttachments(target, item.Id)
+			}
+		case ItemReport:
+			entry.File = fmt.Sprintf("reports/%d.json", item.Id)
+			err = b.saveReport(target, entry.File, item.Id)
+		case ItemSight:
+			entry.File = fmt.Sprintf("sights/%d.json", item.Id)
+			err = b.saveJSON(target, entry.File, fmt.Sprintf("%s/sights/%d?level=3", apiEndpoint, item.Id))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not back up %s %s: %v", item.Type, item.Path, err)
+		}
+		manifest.Items = append(manifest.Items, entry)
+	}
+	if copyUnchanged && len(unchanged) > 0 {
+		if err := copyBackupFiles(b.PreviousPath, unchanged, target); err != nil {
+			return nil, fmt.Errorf("could not copy unchanged sheets from %s: %v", b.PreviousPath, err)
+		}
+	}
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeBytes(target, backupManifestFile, data); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func (b *Backup) saveJSON(target backupTarget, name string, path string) error {
+	raw, err := b.Client.getRaw(path)
+	if err != nil {
+		return err
+	}
+	return writeIndented(target, name, raw)
+}
+
+// Save a report with the rows of every page
+func (b *Backup) saveReport(target backupTarget, name string, reportId int64) error {
+	path := fmt.Sprintf("%s/reports/%d?include=format,objectValue,scope,sourceSheets&level=3&pageSize=%d", apiEndpoint, reportId, backupReportPageSize)
+	raw, err := b.Client.getRaw(path + "&page=1")
+	if err != nil {
+		return err
+	}
+	var page struct {
+		TotalRowCount int               `json:"totalRowCount"`
+		Rows          []json.RawMessage `json:"rows"`
+	}
+	if err := json.Unmarshal(raw, &page); err != nil {
+		return err
+	}
+	rows := page.Rows
+	for n := 2; len(page.Rows) > 0 && len(rows) < page.TotalRowCount; n++ {
+		next, err := b.Client.getRaw(fmt.Sprintf("%s&page=%d", path, n))
+		if err != nil {
+			return err
+		}
+		page.Rows = nil
+		if err := json.Unmarshal(next, &page); err != nil {
+			return err
+		}
+		rows = append(rows, page.Rows...)
+	}
+	if len(rows) > 0 {
+		if raw, err = replaceJSONField(raw, "rows", rows); err != nil {
+			return err
+		}
+	}
+	return writeIndented(target, name, raw)
+}
+
+func (b *Backup) saveAttachments(target backupTarget, sheetId int64) ([]string, error) {
+	page, err := b.Client.ListAttachments(sheetId, &PageOptions{IncludeAll: true})
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, attachment := range page.Data {
+		if attachment.AttachmentType != "FILE" {
+			continue
+		}
+		r, err := b.Client.OpenAttachment(sheetId, attachment.Id)
+		if err != nil {
+			return nil, err
+		}
+		name := fmt.Sprintf("sheets/%d/attachments/%d-%s", sheetId, attachment.Id, strings.NewReplacer("/", "_", `\`, "_").Replace(attachment.Name))
+		err = target.write(name, -1, r)
+		r.Close()
+		if err != nil {
+			return nil, fmt.Errorf("could not save attachment %d: %v", attachment.Id, err)
+		}
+		files = append(files, name)
+	}
+	return files, nil
+}
+
+// loadedItem is an item of a loaded container and the id of the folder holding it
+type loadedItem struct {
+	WalkItem
+	parentId int64
+}
+
+// Return the items of a container loaded with loadAll, followed by the items of its folders
+func loadedItems(path string, parentId int64, sheets []Sheet, reports []Report, sights []Sight, templates []Template, folders []Folder) []loadedItem {
+	var items []loadedItem
+	for _, item := range containerItems(path, sheets, reports, sights, templates, folders) {
+		items = append(items, loadedItem{WalkItem: item, parentId: parentId})
+	}
+	for _, item := range items {
+		if item.Type == ItemFolder {
+			f := item.Folder
+			items = append(items, loadedItems(item.Path, item.Id, f.Sheets, f.Reports, f.Sights, f.Templates, f.Folders)...)
+		}
+	}
+	return items
+}
+
+// Return the JSON object with the value of key replaced, keeping the order of its attributes
+func replaceJSONField(data json.RawMessage, key string, value interface{}) (json.RawMessage, error) {
+	keys, fields, ok := jsonObject(data)
+	if !ok {
+		return nil, fmt.Errorf("not a JSON object")
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := fields[key]; !ok {
+		keys = append(keys, key)
+	}
+	fields[key] = encoded
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(k)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(fields[k])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+type dirTarget string
+
+func (d dirTarget) write(name string, size int64, r io.Reader) error {
+	path := filepath.Join(string(d), filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if cErr := f.Close(); err == nil {
+		err = cErr
+	}
+	return err
+}
+
+type tarTarget struct {
+	w *tar.Writer
+}
+
+// Tar headers need the size up front, so content of unknown size is spooled to a temporary file first
+func (t *tarTarget) write(name string, size int64, r io.Reader) error {
+	if size < 0 {
+		tmp, err := ioutil.TempFile("", "smartsheet-backup")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmp.Name())
+		defer tmp.Close()
+		if size, err = io.Copy(tmp, r); err != nil {
+			return err
+		}
+		if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		r = tmp
+	}
+	header := &tar.Header{Name: name, Mode: 0644, Size: size, ModTime: time.Now()}
+	if err := t.w.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.CopyN(t.w, r, size)
+	return err
+}
+
+func writeIndented(target backupTarget, name string, data json.RawMessage) error {
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, data, "", "  "); err != nil {
+		return err
+	}
+	return writeBytes(target, name, indented.Bytes())
+}
+
+func writeBytes(target backupTarget, name string, data []byte) error {
+	return target.write(name, int64(len(data)), bytes.NewReader(data))
+}
+
+// Copy the named files of a backup directory or tar.gz archive into target
+func copyBackupFiles(path string, names []string, target backupTarget) error {
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		for _, name := range names {
+			if err := copyBackupFile(filepath.Join(path, filepath.FromSlash(name)), name, target); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if wanted[header.Name] {
+			if err := target.write(header.Name, header.Size, tr); err != nil {
+				return err
+			}
+			delete(wanted, header.Name)
+		}
+	}
+	for _, name := range names {
+		if wanted[name] {
+			return fmt.Errorf("%s not found", name)
+		}
+	}
+	return nil
+}
+
+func copyBackupFile(path string, name string, target backupTarget) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	return target.write(name, info.Size(), f)
+}
+
+// Remove the files of the previous backup in dir that the new backup no longer lists,
+// along with directories left empty
+func removeStaleFiles(dir string, previous *BackupManifest, manifest *BackupManifest) error {
+	current := map[string]bool{}
+	for _, item := range manifest.Items {
+		current[item.File] = true
+		for _, name := range item.Attachments {
+			current[name] = true
+		}
+	}
+	for _, item := range previous.Items {
+		for _, name := range append([]string{item.File}, item.Attachments...) {
+			if name == "" || current[name] {
+				continue
+			}
+			path := filepath.Join(dir, filepath.FromSlash(name))
+			if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+			// Removing a directory fails while it still holds files, which is expected
+			for parent := filepath.Dir(path); parent != filepath.Clean(dir); parent = filepath.Dir(parent) {
+				if os.Remove(parent) != nil {
+					break
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Return the content of a file in a backup directory or tar.gz archive
+func readBackupFile(path string, name string) ([]byte, error) {
+	files, err := readBackupFiles(path, []string{name})
+	if err != nil {
+		return nil, err
+	}
+	data, ok := files[name]
+	if !ok {
+		return nil, fmt.Errorf("%s not found in %s", name, path)
+	}
+	return data, nil
+}
+
+// Return the content of the named files of a backup directory or tar.gz archive. Missing files
+// are left out.
+func readBackupFiles(path string, names []string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	wanted := map[string]bool{}
+	for _, name := range names {
+		wanted[name] = true
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		for name := range wanted {
+			data, err := ioutil.ReadFile(filepath.Join(path, filepath.FromSlash(name)))
+			if os.IsNotExist(err) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			files[name] = data
+		}
+		return files, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, err
+	}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return files, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if wanted[header.Name] {
+			if files[header.Name], err = ioutil.ReadAll(tr); err != nil {
+				return nil, err
+			}
+		}
+	}
+}
@@ -0,0 +1,214 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func backupTestServer(sheetRequests *int32) (*Client, func()) {
+	var serverURL string
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/workspaces/1":
+			_, _ = w.Write([]byte(`{"id":1,"name":"Golden","sheets":[{"id":10,"name":"Plan","version":4}],
+				"folders":[{"id":2,"name":"Reporting","reports":[{"id":20,"name":"Open"}],"sights":[{"id":30,"name":"Status"}]}]}`))
+		case "/sheets/10":
+			atomic.AddInt32(sheetRequests, 1)
+			_, _ = w.Write([]byte(`{"id":10,"name":"Plan","version":4,"columns":[{"id":100,"title":"Task"}],"rows":[{"id":1000,"cells":[{"columnId":100,"value":"Write","format":",,1"}]}]}`))
+		case "/sheets/10/attachments":
+			_, _ = w.Write([]byte(`{"data":[{"id":40,"name":"spec.pdf","attachmentType":"FILE"},{"id":41,"name":"Site","attachmentType":"LINK"}]}`))
+		case "/sheets/10/attachments/40":
+			_, _ = w.Write([]byte(`{"id":40,"name":"spec.pdf","attachmentType":"FILE","url":"` + serverURL + `/files/40"}`))
+		case "/files/40":
+			_, _ = w.Write([]byte("%PDF"))
+		case "/reports/20":
+			// Two rows, served one page at a time
+			page := r.URL.Query().Get("page")
+			_, _ = w.Write([]byte(`{"id":20,"name":"Open","totalRowCount":2,"rows":[{"id":` + page + `}]}`))
+		case "/sights/30":
+			_, _ = w.Write([]byte(`{"id":30,"name":"Status","widgets":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":1006,"message":"Not Found"}`))
+		}
+	})
+	serverURL = apiEndpoint
+	return client, done
+}
+
+func TestBackup_ToDirectory(t *testing.T) {
+	var sheetRequests int32
+	client, done := backupTestServer(&sheetRequests)
+	defer done()
+	dir, err := ioutil.TempDir("", "backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backup := client.NewBackup(1)
+	backup.Attachments = true
+	manifest, err := backup.ToDirectory(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, "Golden", manifest.WorkspaceName)
+	assert.Equal(t, []BackupItem{
+		{Type: ItemSheet, Id: 10, Name: "Plan", Path: "Plan", Version: 4, File: "sheets/10.json", Attachments: []string{"sheets/10/attachments/40-spec.pdf"}},
+		{Type: ItemFolder, Id: 2, Name: "Reporting", Path: "Reporting"},
+		{Type: ItemReport, Id: 20, Name: "Open", Path: "Reporting/Open", ParentId: 2, File: "reports/20.json"},
+		{Type: ItemSight, Id: 30, Name: "Status", Path: "Reporting/Status", ParentId: 2, File: "sights/30.json"},
+	}, manifest.Items)
+
+	sheet, err := ioutil.ReadFile(filepath.Join(dir, "sheets", "10.json"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(sheet), "\n  \"columns\": [")
+	pdf, err := ioutil.ReadFile(filepath.Join(dir, "sheets", "10", "attachments", "40-spec.pdf"))
+	assert.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdf))
+	report, err := ioutil.ReadFile(filepath.Join(dir, "reports", "20.json"))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":20,"name":"Open","totalRowCount":2,"rows":[{"id":1},{"id":2}]}`, string(report))
+
+	previous, err := ReadBackupManifest(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.Items, previous.Items)
+
+	backup.Previous = previous
+	manifest, err = backup.ToDirectory(dir)
+	assert.NoError(t, err)
+	assert.True(t, manifest.Items[0].Unchanged)
+	assert.Equal(t, previous.Items[0].Attachments, manifest.Items[0].Attachments)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&sheetRequests))
+}
+
+func TestBackup_ToArchive(t *testing.T) {
+	var sheetRequests int32
+	client, done := backupTestServer(&sheetRequests)
+	defer done()
+	dir, err := ioutil.TempDir("", "backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	archive := filepath.Join(dir, "golden.tar.gz")
+	manifest, err := client.NewBackup(1).ToArchive(archive)
+	assert.NoError(t, err)
+	assert.Len(t, manifest.Items, 4)
+	assert.Empty(t, manifest.Items[0].Attachments)
+
+	read, err := ReadBackupManifest(archive)
+	assert.NoError(t, err)
+	assert.Equal(t, manifest.WorkspaceId, read.WorkspaceId)
+	sight, err := readBackupFile(archive, "sights/30.json")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"id":30,"name":"Status","widgets":[]}`, string(sight))
+	_, err = readBackupFile(archive, "sheets/99.json")
+	assert.Error(t, err)
+}
+
+func TestBackup_IncrementalArchive(t *testing.T) {
+	var sheetRequests int32
+	client, done := backupTestServer(&sheetRequests)
+	defer done()
+	dir, err := ioutil.TempDir("", "backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	first := filepath.Join(dir, "first.tar.gz")
+	backup := client.NewBackup(1)
+	backup.Attachments = true
+	previous, err := backup.ToArchive(first)
+	assert.NoError(t, err)
+
+	backup.Previous = previous
+	_, err = backup.ToArchive(filepath.Join(dir, "second.tar.gz"))
+	assert.Error(t, err)
+
+	second := filepath.Join(dir, "second.tar.gz")
+	backup.PreviousPath = first
+	manifest, err := backup.ToArchive(second)
+	assert.NoError(t, err)
+	assert.True(t, manifest.Items[0].Unchanged)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&sheetRequests))
+
+	// The second archive is complete on its own
+	files, err := readBackupFiles(second, []string{"sheets/10.json", "sheets/10/attachments/40-spec.pdf", "sights/30.json"})
+	assert.NoError(t, err)
+	assert.Len(t, files, 3)
+	assert.Equal(t, "%PDF", string(files["sheets/10/attachments/40-spec.pdf"]))
+	assert.Contains(t, string(files["sheets/10.json"]), `"Write"`)
+}
+
+func TestBackup_IncrementalDirectoryRemovesDeletedItems(t *testing.T) {
+	var sheetRequests int32
+	client, done := backupTestServer(&sheetRequests)
+	defer done()
+	dir, err := ioutil.TempDir("", "backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backup := client.NewBackup(1)
+	previous, err := backup.ToDirectory(dir)
+	assert.NoError(t, err)
+
+	// Sheet 11 was backed up earlier and has been deleted since
+	target := dirTarget(dir)
+	assert.NoError(t, writeBytes(target, "sheets/11.json", []byte(`{}`)))
+	assert.NoError(t, writeBytes(target, "sheets/11/attachments/50-old.txt", []byte("old")))
+	previous.Items = append(previous.Items, BackupItem{Type: ItemSheet, Id: 11, Name: "Old", Path: "Old", Version: 2,
+		File: "sheets/11.json", Attachments: []string{"sheets/11/attachments/50-old.txt"}})
+
+	backup.Previous = previous
+	_, err = backup.ToDirectory(dir)
+	assert.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dir, "sheets", "11.json"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "sheets", "11"))
+	assert.True(t, os.IsNotExist(err))
+	_, err = os.Stat(filepath.Join(dir, "sheets", "10.json"))
+	assert.NoError(t, err)
+}
+
+func TestBackup_IncrementalWithAttachmentsTurnedOn(t *testing.T) {
+	var sheetRequests int32
+	client, done := backupTestServer(&sheetRequests)
+	defer done()
+	dir, err := ioutil.TempDir("", "backup")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	backup := client.NewBackup(1)
+	previous, err := backup.ToDirectory(dir)
+	assert.NoError(t, err)
+	assert.False(t, previous.Attachments)
+
+	// The unchanged sheet is backed up again, as its attachments were not saved the first time
+	backup.Previous = previous
+	backup.Attachments = true
+	manifest, err := backup.ToDirectory(dir)
+	assert.NoError(t, err)
+	assert.True(t, manifest.Attachments)
+	assert.False(t, manifest.Items[0].Unchanged)
+	assert.Equal(t, []string{"sheets/10/attachments/40-spec.pdf"}, manifest.Items[0].Attachments)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&sheetRequests))
+	pdf, err := ioutil.ReadFile(filepath.Join(dir, "sheets", "10", "attachments", "40-spec.pdf"))
+	assert.NoError(t, err)
+	assert.Equal(t, "%PDF", string(pdf))
+}
@@ -0,0 +1,276 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBulkChunkSize  = 500
+	defaultBulkWorkers    = 4
+	defaultBulkMaxRetries = 5
+	defaultBulkBackoff    = time.Second
+	maxBulkBackoff        = time.Minute
+	// Row Ids to delete are sent in the query string, so delete chunks are kept small
+	maxDeleteChunkSize = 100
+)
+
+// BulkWriter adds, updates and deletes large numbers of rows by splitting them into
+// API sized chunks and sending the chunks concurrently. Rate limited (HTTP 429) requests
+// are retried with exponential backoff. Updates and deletes are also retried after server
+// errors and transport failures; adds are not, as the first attempt may have created the rows.
+type BulkWriter struct {
+	Client            Client
+	SheetId           int64
+	ChunkSize         int                   // Rows per request. Defaults to 500
+	Workers           int                   // Number of requests in flight. Defaults to 4
+	MaxRetries        int                   // Retries per chunk for rate limited or failed requests. Defaults to 5
+	Backoff           time.Duration         // Delay before the first retry, doubled for each further retry. Defaults to 1 second
+	RequestsPerMinute int                   // When set, requests are spaced to stay under this rate
+	Progress          func(done, total int) // Called after each chunk with the number of rows processed so far
+	sleep             func(d time.Duration) // Replaced in tests
+}
+
+// BulkResult holds the outcome of a bulk operation, in the order rows were passed in
+type BulkResult struct {
+	Rows     []Row             // Rows returned by the API. Failed items are left as the zero Row. Empty for deletes.
+	RowIds   []int64           // Row Ids of the written or deleted rows. Zero for failed items.
+	Failures []BulkItemFailure // Failed items. Index refers to the position in the input.
+}
+
+type bulkChunk struct {
+	start, end int
+}
+
+// Return a BulkWriter for the sheet with default settings
+func (c Client) NewBulkWriter(sheetId int64) *BulkWriter {
+	return &BulkWriter{
+		Client:  c,
+		SheetId: sheetId,
+	}
+}
+
+// Add rows to the sheet. An error is returned if any row failed, alongside the result.
+func (w *BulkWriter) AddRows(rows []Row) (*BulkResult, error) {
+	return w.writeRows("POST", rows)
+}
+
+// Update rows in the sheet. An error is returned if any row failed, alongside the result.
+func (w *BulkWriter) UpdateRows(rows []Row) (*BulkResult, error) {
+	return w.writeRows("PUT", rows)
+}
+
+// Delete rows from the sheet. Rows that no longer exist are ignored and keep a zero Id in the result.
+// An error is returned if any chunk failed, alongside the result.
+func (w *BulkWriter) DeleteRows(rowIds []int64) (*BulkResult, error) {
+	result := &BulkResult{RowIds: make([]int64, len(rowIds))}
+	chunkSize := w.chunkSize()
+	if chunkSize > maxDeleteChunkSize {
+		chunkSize = maxDeleteChunkSize
+	}
+	var mu sync.Mutex
+	w.run(len(rowIds), chunkSize, func(chunk bulkChunk) {
+		ids := rowIds[chunk.start:chunk.end]
+		var deleted []int64
+		res := ResultObject{Result: &deleted}
+		err := w.retry(func() (*http.Response, error) {
+			return w.Client.delete(fmt.Sprintf("%s/sheets/%d/rows?ids=%s&ignoreRowsNotFound=true", apiEndpoint, w.SheetId, joinIds(ids)))
+		}, &res, true)
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			result.fail(chunk, err)
+			return
+		}
+		found := map[int64]bool{}
+		for _, id := range deleted {
+			found[id] = true
+		}
+		for i, id := range ids {
+			if found[id] {
+				result.RowIds[chunk.start+i] = id
+			}
+		}
+	})
+	return result, result.err(len(rowIds))
+}
+
+func (w *BulkWriter) writeRows(method string, rows []Row) (*BulkResult, error) {
+	result := &BulkResult{
+		Rows:   make([]Row, len(rows)),
+		RowIds: make([]int64, len(rows)),
+	}
+	var mu sync.Mutex
+	w.run(len(rows), w.chunkSize(), func(chunk bulkChunk) {
+		var written []Row
+		res := ResultObject{Result: &written}
+		err := w.retry(func() (*http.Response, error) {
+			path := fmt.Sprintf("%s/sheets/%d/rows?allowPartialSuccess=true", apiEndpoint, w.SheetId)
+			if method == "PUT" {
+				return w.Client.put(path, rows[chunk.start:chunk.end], nil)
+			}
+			return w.Client.post(path, rows[chunk.start:chunk.end], nil)
+		}, &res, method == "PUT")
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			result.fail(chunk, err)
+			return
+		}
+		// With partial success the result holds only the rows that succeeded, in request order
+		failed := map[int]bool{}
+		for _, f := range res.FailedItems {
+			failed[f.Index] = true
+			f.Index += chunk.start
+			result.Failures = append(result.Failures, f)
+		}
+		next := 0
+		for i := chunk.start; i < chunk.end && next < len(written); i++ {
+			if failed[i-chunk.start] {
+				continue
+			}
+			result.Rows[i] = written[next]
+			result.RowIds[i] = written[next].Id
+			next++
+		}
+	})
+	return result, result.err(len(rows))
+}
+
+// Split total items into chunks and hand them to a bounded pool of workers
+func (w *BulkWriter) run(total, chunkSize int, send func(chunk bulkChunk)) {
+	chunks := make(chan bulkChunk)
+	go func() {
+		defer close(chunks)
+		for start := 0; start < total; start += chunkSize {
+			end := start + chunkSize
+			if end > total {
+				end = total
+			}
+			chunks <- bulkChunk{start: start, end: end}
+		}
+	}()
+
+	var throttle <-chan time.Time
+	if w.RequestsPerMinute > 0 {
+		ticker := time.NewTicker(time.Minute / time.Duration(w.RequestsPerMinute))
+		defer ticker.Stop()
+		throttle = ticker.C
+	}
+
+	workers := w.Workers
+	if workers <= 0 {
+		workers = defaultBulkWorkers
+	}
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		done int
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range chunks {
+				if throttle != nil {
+					<-throttle
+				}
+				send(chunk)
+				if w.Progress != nil {
+					mu.Lock()
+					done += chunk.end - chunk.start
+					w.Progress(done, total)
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// Call the API and decode the response into res. Rate limited calls are retried. Server errors and
+// transport failures are retried only for idempotent calls, since they may have been applied.
+func (w *BulkWriter) retry(call func() (*http.Response, error), res *ResultObject, idempotent bool) error {
+	maxRetries := w.MaxRetries
+	if maxRetries <= 0 {
+		maxRetries = defaultBulkMaxRetries
+	}
+	backoff := w.Backoff
+	if backoff <= 0 {
+		backoff = defaultBulkBackoff
+	}
+	sleep := w.sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	for attempt := 0; ; attempt++ {
+		resp, err := call()
+		if err == nil {
+			if dErr := w.Client.decodeJSON(resp, res); dErr != nil {
+				return fmt.Errorf("could not decode JSON response: %v", dErr)
+			}
+			return nil
+		}
+		rateLimited := resp != nil && resp.StatusCode == http.StatusTooManyRequests
+		if attempt >= maxRetries || (!rateLimited && (!idempotent || (resp != nil && resp.StatusCode < 500))) {
+			return err
+		}
+		delay := backoff << uint(attempt)
+		if resp != nil {
+			if seconds, aErr := strconv.Atoi(resp.Header.Get("Retry-After")); aErr == nil {
+				delay = time.Duration(seconds) * time.Second
+			}
+		}
+		if delay > maxBulkBackoff {
+			delay = maxBulkBackoff
+		}
+		sleep(delay)
+	}
+}
+
+func (w *BulkWriter) chunkSize() int {
+	if w.ChunkSize <= 0 {
+		return defaultBulkChunkSize
+	}
+	return w.ChunkSize
+}
+
+// Record every item of a chunk as failed
+func (r *BulkResult) fail(chunk bulkChunk, err error) {
+	for i := chunk.start; i < chunk.end; i++ {
+		r.Failures = append(r.Failures, BulkItemFailure{
+			Index: i,
+			Error: ErrorObject{Message: err.Error()},
+		})
+	}
+}
+
+func (r *BulkResult) err(total int) error {
+	if len(r.Failures) == 0 {
+		return nil
+	}
+	sort.Slice(r.Failures, func(i, j int) bool {
+		return r.Failures[i].Index < r.Failures[j].Index
+	})
+	return fmt.Errorf("%d of %d rows failed, first failure at index %d: %s", len(r.Failures), total, r.Failures[0].Index, r.Failures[0].Error.Message)
+}
@@ -0,0 +1,138 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBulkWriter_AddRows(t *testing.T) {
+	var calls int32
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		// Rate limit the first request
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"errorCode":4003,"message":"Rate limit exceeded."}`))
+			return
+		}
+		var rows []Row
+		_ = json.NewDecoder(r.Body).Decode(&rows)
+		res := ResultObject{Message: "SUCCESS"}
+		var written []Row
+		for i, row := range rows {
+			// Fail rows whose first cell is "bad"
+			if row.Cells[0].Value == "bad" {
+				res.FailedItems = append(res.FailedItems, BulkItemFailure{Index: i, Error: ErrorObject{ErrorCode: 1012, Message: "bad row"}})
+				continue
+			}
+			written = append(written, Row{Id: int64(row.Cells[0].Value.(float64)), Cells: row.Cells})
+		}
+		res.Result = written
+		_ = json.NewEncoder(w).Encode(res)
+	})
+	defer done()
+
+	var rows []Row
+	for i := 1; i <= 10; i++ {
+		rows = append(rows, Row{Cells: []Cell{{ColumnId: 1, Value: float64(i)}}})
+	}
+	rows[4].Cells[0].Value = "bad"
+
+	var progress []int
+	writer := client.NewBulkWriter(1)
+	writer.ChunkSize = 3
+	writer.Workers = 1
+	writer.sleep = func(time.Duration) {}
+	writer.Progress = func(done, total int) {
+		progress = append(progress, done)
+		assert.Equal(t, 10, total)
+	}
+	result, err := writer.AddRows(rows)
+	assert.Error(t, err)
+	assert.Equal(t, []int64{1, 2, 3, 4, 0, 6, 7, 8, 9, 10}, result.RowIds)
+	assert.Len(t, result.Failures, 1)
+	assert.Equal(t, 4, result.Failures[0].Index)
+	assert.Equal(t, []int{3, 6, 9, 10}, progress)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+}
+
+func TestBulkWriter_DeleteRows(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		var deleted []int64
+		for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+			// Row 3 no longer exists
+			if id != "3" {
+				var i int64
+				_ = json.Unmarshal([]byte(id), &i)
+				deleted = append(deleted, i)
+			}
+		}
+		_ = json.NewEncoder(w).Encode(ResultObject{Message: "SUCCESS", Result: deleted})
+	})
+	defer done()
+	writer := client.NewBulkWriter(1)
+	writer.ChunkSize = 2
+	result, err := writer.DeleteRows([]int64{1, 2, 3, 4, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 2, 0, 4, 5}, result.RowIds)
+}
+
+func TestBulkWriter_ChunkFailure(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"errorCode":1008,"message":"Unable to parse request."}`))
+	})
+	defer done()
+	writer := client.NewBulkWriter(1)
+	result, err := writer.UpdateRows([]Row{{Id: 1}, {Id: 2}})
+	assert.Error(t, err)
+	assert.Len(t, result.Failures, 2)
+}
+
+func TestBulkWriter_ServerErrorRetry(t *testing.T) {
+	var calls int32
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		// The first request fails with a gateway error, which may hide a committed write
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"errorCode":4000,"message":"Bad gateway."}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ResultObject{Message: "SUCCESS", Result: []Row{{Id: 1}}})
+	})
+	defer done()
+	writer := client.NewBulkWriter(1)
+	writer.sleep = func(time.Duration) {}
+
+	result, err := writer.AddRows([]Row{{Cells: []Cell{{ColumnId: 1, Value: "a"}}}})
+	assert.Error(t, err)
+	assert.Len(t, result.Failures, 1)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	atomic.StoreInt32(&calls, 0)
+	result, err = writer.UpdateRows([]Row{{Id: 1}})
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1}, result.RowIds)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -16,12 +16,18 @@
 
 package smartsheet
 
-import "time"
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"strconv"
+	"time"
+)
 
 type Cell struct {
 	CellHistory
 	ColumnId           int64        `json:"columnId,omitempty"`           //The Id of the column that the cell is located in
-	ColumnType         string       `json:"columnType,omitempty"`         //See type definition on the Column object. Only returned if the include query string parameter contains columnType.
+	ColumnType         ColumnType   `json:"columnType,omitempty"`         //See type definition on the Column object. Only returned if the include query string parameter contains columnType.
 	ConditionalFormat  string       `json:"conditionalFormat,omitempty"`  //The format descriptor describing this cell's conditional format (see Formatting). Only returned if the include query string parameter contains format and this cell has a conditional format applied.
 	DisplayValue       string       `json:"displayValue,omitempty"`       //Visual representation of cell contents, as presented to the user in the UI. See Cell Reference.
 	Format             string       `json:"format,omitempty"`             //The format descriptor (see Formatting) Only returned if the include query string parameter contains format and this cell has a non-default format applied.
@@ -36,23 +42,232 @@ type Cell struct {
 	Value              interface{}  `json:"value,omitempty"`              //string number, or Boolean 	A string, a number, or a Boolean value -- depending on the cell type and the data in the cell. Cell values larger than 4000 characters are silently truncated. An empty cell returns no value. See Cell Reference.
 }
 
+// Return the cell's date value. Dates without a time are returned at midnight UTC.
+func (c Cell) Time() (time.Time, error) {
+	if c.ColumnType != "" && !c.ColumnType.IsDate() {
+		return time.Time{}, fmt.Errorf("cell in column %d is a %s cell, not a date", c.ColumnId, c.ColumnType)
+	}
+	value := c.Value
+	if c.ObjectValue != nil && c.ObjectValue.Value != nil {
+		value = c.ObjectValue.Value
+	}
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, fmt.Errorf("cell in column %d is blank", c.ColumnId)
+	case string:
+		return parseTime(v)
+	}
+	return time.Time{}, fmt.Errorf("cell in column %d holds %T, not a date", c.ColumnId, value)
+}
+
+// Return the cell's numeric value
+func (c Cell) Float() (float64, error) {
+	if c.ColumnType != "" && c.ColumnType != ColumnTypeTextNumber {
+		return 0, fmt.Errorf("cell in column %d is a %s cell, not a number", c.ColumnId, c.ColumnType)
+	}
+	switch v := c.Value.(type) {
+	case nil:
+		return 0, fmt.Errorf("cell in column %d is blank", c.ColumnId)
+	case string:
+		return 0, fmt.Errorf("cell in column %d holds text %q, not a number", c.ColumnId, v)
+	}
+	f, err := toFloat(c.Value)
+	if err != nil {
+		return 0, fmt.Errorf("cell in column %d holds %T, not a number", c.ColumnId, c.Value)
+	}
+	return f, nil
+}
+
+// Return the cell's numeric value as an integer. Numbers with a fractional part are an error.
+func (c Cell) Int() (int64, error) {
+	f, err := c.Float()
+	if err != nil {
+		return 0, err
+	}
+	if f != math.Trunc(f) {
+		return 0, fmt.Errorf("cell in column %d holds %v, not an integer", c.ColumnId, f)
+	}
+	return int64(f), nil
+}
+
+// Return the cell's checkbox value. A blank checkbox cell is unchecked.
+func (c Cell) Bool() (bool, error) {
+	if c.ColumnType != "" && c.ColumnType != ColumnTypeCheckbox {
+		return false, fmt.Errorf("cell in column %d is a %s cell, not a checkbox", c.ColumnId, c.ColumnType)
+	}
+	switch v := c.Value.(type) {
+	case bool:
+		return v, nil
+	case nil:
+		if c.ColumnType == ColumnTypeCheckbox {
+			return false, nil
+		}
+		return false, fmt.Errorf("cell in column %d is blank", c.ColumnId)
+	}
+	return false, fmt.Errorf("cell in column %d holds %T, not a boolean", c.ColumnId, c.Value)
+}
+
+// Return the cell's selected options. MULTI_PICKLIST cells need the objectValue, requested with include=objectValue.
+// A blank cell returns no strings.
+func (c Cell) Strings() ([]string, error) {
+	if c.ObjectValue != nil && c.ObjectValue.ObjectType == ObjectTypeMultiPicklist {
+		options := make([]string, len(c.ObjectValue.Values))
+		for i, value := range c.ObjectValue.Values {
+			if f, ok := value.(float64); ok {
+				options[i] = strconv.FormatFloat(f, 'f', -1, 64)
+			} else {
+				options[i] = fmt.Sprint(value)
+			}
+		}
+		return options, nil
+	}
+	if c.ColumnType == ColumnTypeMultiPicklist && c.Value != nil {
+		return nil, fmt.Errorf("cell in column %d is a MULTI_PICKLIST cell without an objectValue", c.ColumnId)
+	}
+	switch v := c.Value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{v}, nil
+	}
+	return nil, fmt.Errorf("cell in column %d holds %T, not text", c.ColumnId, c.Value)
+}
+
+// Return the cell's contacts. CONTACT_LIST cells without an objectValue return the email
+// from the value and the name from the display value. A blank cell returns no contacts.
+func (c Cell) Contacts() ([]Contact, error) {
+	if c.ColumnType != "" && c.ColumnType != ColumnTypeContactList && c.ColumnType != ColumnTypeMultiContactList {
+		return nil, fmt.Errorf("cell in column %d is a %s cell, not a contact", c.ColumnId, c.ColumnType)
+	}
+	if c.ObjectValue != nil {
+		switch c.ObjectValue.ObjectType {
+		case ObjectTypeContact:
+			if c.ObjectValue.Contact == nil {
+				return nil, fmt.Errorf("cell in column %d has a CONTACT objectValue without a contact", c.ColumnId)
+			}
+			return []Contact{*c.ObjectValue.Contact}, nil
+		case ObjectTypeMultiContact:
+			return c.ObjectValue.Contacts, nil
+		}
+	}
+	switch v := c.Value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if c.ColumnType == ColumnTypeMultiContactList {
+			return nil, fmt.Errorf("cell in column %d is a MULTI_CONTACT_LIST cell without an objectValue", c.ColumnId)
+		}
+		return []Contact{{Email: v, Name: c.DisplayValue}}, nil
+	}
+	return nil, fmt.Errorf("cell in column %d holds %T, not a contact", c.ColumnId, c.Value)
+}
+
+// Return a cell holding text
+func NewTextCell(columnId int64, text string) Cell {
+	return Cell{ColumnId: columnId, Value: text}
+}
+
+// Return a cell holding a number. Any integer or floating point type is accepted.
+func NewNumberCell(columnId int64, number interface{}) (Cell, error) {
+	if _, ok := number.(string); ok {
+		return Cell{}, fmt.Errorf("cannot use %T as a number", number)
+	}
+	f, err := toFloat(number)
+	if err != nil {
+		return Cell{}, fmt.Errorf("cannot use %T as a number", number)
+	}
+	return Cell{ColumnId: columnId, Value: f}, nil
+}
+
+// Return a CHECKBOX cell
+func NewBoolCell(columnId int64, checked bool) Cell {
+	return Cell{ColumnId: columnId, Value: checked}
+}
+
+// Return a DATE cell. Only the date part of t is sent.
+func NewDateCell(columnId int64, t time.Time) Cell {
+	return Cell{ColumnId: columnId, Value: t.Format("2006-01-02")}
+}
+
+// Return an ABSTRACT_DATETIME cell. The date and time of t are sent without a time zone.
+func NewDateTimeCell(columnId int64, t time.Time) Cell {
+	return Cell{ColumnId: columnId, Value: t.Format("2006-01-02T15:04:05")}
+}
+
+// Return a CONTACT_LIST cell
+func NewContactCell(columnId int64, contact Contact) Cell {
+	return Cell{ColumnId: columnId, ObjectValue: &ObjectValue{ObjectType: ObjectTypeContact, Contact: &contact}}
+}
+
+// Return a MULTI_CONTACT_LIST cell
+func NewMultiContactCell(columnId int64, contacts ...Contact) Cell {
+	return Cell{ColumnId: columnId, ObjectValue: &ObjectValue{ObjectType: ObjectTypeMultiContact, Contacts: contacts}}
+}
+
+// Return a MULTI_PICKLIST cell
+func NewMultiPicklistCell(columnId int64, options ...string) Cell {
+	values := make([]interface{}, len(options))
+	for i, option := range options {
+		values[i] = option
+	}
+	return Cell{ColumnId: columnId, ObjectValue: &ObjectValue{ObjectType: ObjectTypeMultiPicklist, Values: values}}
+}
+
+// Return a PREDECESSOR cell
+func NewPredecessorCell(columnId int64, predecessors ...Predecessor) Cell {
+	return Cell{ColumnId: columnId, ObjectValue: &ObjectValue{ObjectType: ObjectTypePredecessorList, Predecessors: predecessors}}
+}
+
+// CellHistoryOptions controls which cell history entries GetCellHistory returns
+type CellHistoryOptions struct {
+	PageOptions
+	IncludeColumnType bool // Set Cell.ColumnType on each entry
+}
+
+// CellHistoryPage is a page of cell history returned by GetCellHistory. Entries are ordered
+// newest first and carry the cell's value along with when and by whom it was set.
+type CellHistoryPage struct {
+	PageInfo
+	Data []Cell `json:"data"`
+}
+
+// Return CellHistoryPage object
+func (c Client) GetCellHistory(sheetId int64, rowId int64, columnId int64, options *CellHistoryOptions) (*CellHistoryPage, error) {
+	var page CellHistoryPage
+	query := url.Values{}
+	if options != nil {
+		options.PageOptions.setQuery(query)
+		if options.IncludeColumnType {
+			query.Set("include", "columnType")
+		}
+	}
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/sheets/%d/rows/%d/columns/%d/history", apiEndpoint, sheetId, rowId, columnId), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &page); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &page, nil
+}
+
 type CellHistory struct {
-	ModifiedAt *time.Time `json:"modifiedAt,omitempty"`
-	ModifiedBy *User      `json:"modifiedBy,omitempty"`
+	ModifiedAt *time.Time `json:"modifiedAt,omitempty"` // Time the cell was modified. Only returned in cell history
+	ModifiedBy *User      `json:"modifiedBy,omitempty"` // User object containing name and email of the user who modified the cell. Only returned in cell history
 }
 
 type CellLink struct {
-	ColumnId  int64  `json:"columnId,omitempty"`  // Column Id of the linked cell
-	RowId     int    `json:"rowId,omitempty"`     // Row Id of the linked cell
-	SheetId   int    `json:"sheetId,omitempty"`   // Sheet Id of the sheet that the linked cell belongs to
-	SheetName string `json:"sheetName,omitempty"` // Sheet name of the linked cell
-	Status    string `json:"status,omitempty"`
+	ColumnId  int64          `json:"columnId,omitempty"`  // Column Id of the linked cell
+	RowId     int64          `json:"rowId,omitempty"`     // Row Id of the linked cell
+	SheetId   int64          `json:"sheetId,omitempty"`   // Sheet Id of the sheet that the linked cell belongs to
+	SheetName string         `json:"sheetName,omitempty"` // Sheet name of the linked cell
+	Status    CellLinkStatus `json:"status,omitempty"`    // One of the CellLinkStatus values. Only present in responses
 }
 
 type Hyperlink struct {
-	ReportId int    `json:"reportId,omitempty"` // If non-null, this hyperlink is a link to the report with this Id.
-	SheetId  int    `json:"sheetId,omitempty"`  // If non-null, this hyperlink is a link to the sheet with this Id.
-	SightId  int    `json:"sightId,omitempty"`  // If non-null, this hyperlink is a link to the Sight with this Id.
+	ReportId int64  `json:"reportId,omitempty"` // If non-null, this hyperlink is a link to the report with this Id.
+	SheetId  int64  `json:"sheetId,omitempty"`  // If non-null, this hyperlink is a link to the sheet with this Id.
+	SightId  int64  `json:"sightId,omitempty"`  // If non-null, this hyperlink is a link to the Sight with this Id.
 	Url      string `json:"url,omitempty"`      // When the hyperlink is a URL link, this property contains the URL value. When the hyperlink is a sheet/report/Sight link (that is, sheetId, reportId, or sightId is non-null), this property contains the permalink to the sheet, report, or Sight.
 }
 
@@ -62,7 +277,3 @@ type Image struct {
 	Height  int    `json:"height,omitempty"`  // Original height (in pixels) of the uploaded image
 	Width   int    `json:"width,omitempty"`   // Original width (in pixels) of the uploaded image
 }
-
-type ObjectValue struct {
-	ObjectType string `json:"objectType,omitempty"`
-}
@@ -0,0 +1,117 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestCell_Accessors(t *testing.T) {
+	date := Cell{ColumnId: 1, ColumnType: ColumnTypeDate, Value: "2026-10-19"}
+	got, err := date.Time()
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), got)
+	_, err = date.Float()
+	assert.Error(t, err)
+
+	number := Cell{ColumnId: 2, Value: 12.0}
+	f, err := number.Float()
+	assert.NoError(t, err)
+	assert.Equal(t, 12.0, f)
+	i, err := number.Int()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(12), i)
+	_, err = Cell{Value: 1.5}.Int()
+	assert.Error(t, err)
+	_, err = Cell{Value: "12"}.Float()
+	assert.Error(t, err)
+
+	checked, err := Cell{ColumnType: ColumnTypeCheckbox}.Bool()
+	assert.NoError(t, err)
+	assert.False(t, checked)
+	_, err = Cell{Value: "yes"}.Bool()
+	assert.Error(t, err)
+
+	options, err := NewMultiPicklistCell(3, "A", "B").Strings()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, options)
+	_, err = Cell{ColumnType: ColumnTypeMultiPicklist, Value: "A, B"}.Strings()
+	assert.Error(t, err)
+
+	contacts, err := Cell{ColumnType: ColumnTypeContactList, Value: "jane@example.com", DisplayValue: "Jane"}.Contacts()
+	assert.NoError(t, err)
+	assert.Equal(t, []Contact{{Email: "jane@example.com", Name: "Jane"}}, contacts)
+	contacts, err = NewMultiContactCell(4, Contact{Email: "a@example.com"}, Contact{Email: "b@example.com"}).Contacts()
+	assert.NoError(t, err)
+	assert.Len(t, contacts, 2)
+	_, err = Cell{ColumnType: ColumnTypeCheckbox, Value: true}.Contacts()
+	assert.Error(t, err)
+	_, err = Cell{ColumnType: ColumnTypeContactList, ObjectValue: &ObjectValue{ObjectType: ObjectTypeContact}}.Contacts()
+	assert.Error(t, err)
+}
+
+func TestCell_Constructors(t *testing.T) {
+	day := time.Date(2026, 10, 19, 15, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		cell Cell
+		want string
+	}{
+		{"text", NewTextCell(1, "Open"), `{"columnId":1,"value":"Open"}`},
+		{"bool", NewBoolCell(1, true), `{"columnId":1,"value":true}`},
+		{"date", NewDateCell(1, day), `{"columnId":1,"value":"2026-10-19"}`},
+		{"datetime", NewDateTimeCell(1, day), `{"columnId":1,"value":"2026-10-19T15:30:00"}`},
+		{"contact", NewContactCell(1, Contact{Email: "a@example.com"}), `{"columnId":1,"objectValue":{"objectType":"CONTACT","email":"a@example.com"}}`},
+		{"predecessor", NewPredecessorCell(1, Predecessor{RowId: 5, Type: "FS"}), `{"columnId":1,"objectValue":{"objectType":"PREDECESSOR_LIST","predecessors":[{"rowId":5,"type":"FS"}]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := json.Marshal(tt.cell)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(out))
+		})
+	}
+
+	cell, err := NewNumberCell(1, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7.0, cell.Value)
+	_, err = NewNumberCell(1, "7")
+	assert.Error(t, err)
+}
+
+func TestClient_GetCellHistory(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sheets/1/rows/2/columns/3/history", r.URL.Path)
+		assert.Equal(t, "columnType", r.URL.Query().Get("include"))
+		assert.Equal(t, "2", r.URL.Query().Get("page"))
+		_, _ = w.Write([]byte(`{"pageNumber":2,"totalPages":2,"data":[
+			{"columnId":3,"columnType":"PICKLIST","value":"Closed","modifiedAt":"2026-10-19T10:00:00Z","modifiedBy":{"email":"a@example.com","name":"A"}},
+			{"columnId":3,"columnType":"PICKLIST","value":"Open","modifiedAt":"2026-10-01T10:00:00Z","modifiedBy":{"email":"b@example.com","name":"B"}}
+		]}`))
+	})
+	defer done()
+	page, err := client.GetCellHistory(1, 2, 3, &CellHistoryOptions{PageOptions: PageOptions{Page: 2}, IncludeColumnType: true})
+	assert.NoError(t, err)
+	assert.Len(t, page.Data, 2)
+	assert.Equal(t, "Closed", page.Data[0].Value)
+	assert.Equal(t, "a@example.com", page.Data[0].ModifiedBy.Email)
+	assert.Equal(t, time.Date(2026, 10, 1, 10, 0, 0, 0, time.UTC), *page.Data[1].ModifiedAt)
+}
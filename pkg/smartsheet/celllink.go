@@ -0,0 +1,117 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// InboundLink links a cell to a source cell in another sheet, so the cell mirrors the source cell's value
+type InboundLink struct {
+	RowId    int64 // Row Id of the cell to link
+	ColumnId int64 // Column Id of the cell to link
+	Source   CellLink
+}
+
+// LinkedCell is a cell holding a cell link, as found by Sheet.InboundLinks and Sheet.OutboundLinks
+type LinkedCell struct {
+	RowId    int64    // Row Id of the cell holding the link
+	ColumnId int64    // Column Id of the cell holding the link
+	Inbound  bool     // True if the cell mirrors Link, false if Link mirrors the cell
+	Link     CellLink // The other end of the link
+}
+
+// Return a cell linked to a source cell in another sheet
+func NewCellLinkCell(columnId int64, sourceSheetId int64, sourceRowId int64, sourceColumnId int64) Cell {
+	return Cell{
+		ColumnId: columnId,
+		LinkInFromCell: &CellLink{
+			SheetId:  sourceSheetId,
+			RowId:    sourceRowId,
+			ColumnId: sourceColumnId,
+		},
+	}
+}
+
+// A linked cell must be sent with an explicit null value
+func (c Cell) MarshalJSON() ([]byte, error) {
+	type cell Cell
+	if c.LinkInFromCell == nil || c.Value != nil {
+		return json.Marshal(cell(c))
+	}
+	return json.Marshal(struct {
+		cell
+		Value interface{} `json:"value"`
+	}{cell: cell(c)})
+}
+
+// Return updated Row objects. Links to cells in the same row are sent in a single row update.
+func (c Client) CreateCellLinks(sheetId int64, links []InboundLink) (*[]Row, error) {
+	var rows []Row
+	index := map[int64]int{}
+	for _, link := range links {
+		if link.Source.SheetId == 0 || link.Source.RowId == 0 || link.Source.ColumnId == 0 {
+			return nil, fmt.Errorf("link to row %d column %d needs a source sheet, row and column", link.RowId, link.ColumnId)
+		}
+		i, ok := index[link.RowId]
+		if !ok {
+			i = len(rows)
+			index[link.RowId] = i
+			rows = append(rows, Row{Id: link.RowId})
+		}
+		rows[i].Cells = append(rows[i].Cells, NewCellLinkCell(link.ColumnId, link.Source.SheetId, link.Source.RowId, link.Source.ColumnId))
+	}
+	return c.UpdateRows(sheetId, rows)
+}
+
+// Return every cell of the sheet that mirrors a cell in another sheet
+func (s Sheet) InboundLinks() []LinkedCell {
+	var links []LinkedCell
+	for _, row := range s.Rows {
+		for _, cell := range row.Cells {
+			if cell.LinkInFromCell != nil {
+				links = append(links, LinkedCell{RowId: row.Id, ColumnId: cell.ColumnId, Inbound: true, Link: *cell.LinkInFromCell})
+			}
+		}
+	}
+	return links
+}
+
+// Return every link from a cell of the sheet to a cell in another sheet
+func (s Sheet) OutboundLinks() []LinkedCell {
+	var links []LinkedCell
+	for _, row := range s.Rows {
+		for _, cell := range row.Cells {
+			for _, link := range cell.LinksOutToCells {
+				links = append(links, LinkedCell{RowId: row.Id, ColumnId: cell.ColumnId, Link: link})
+			}
+		}
+	}
+	return links
+}
+
+// Return every inbound and outbound link of the sheet whose status is BROKEN, BLOCKED or INACCESSIBLE
+func (s Sheet) BrokenLinks() []LinkedCell {
+	var broken []LinkedCell
+	for _, link := range append(s.InboundLinks(), s.OutboundLinks()...) {
+		if link.Link.Status.IsBroken() {
+			broken = append(broken, link)
+		}
+	}
+	return broken
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+func TestNewCellLinkCell(t *testing.T) {
+	out, err := json.Marshal(NewCellLinkCell(1, 100, 200, 300))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"columnId":1,"value":null,"linkInFromCell":{"sheetId":100,"rowId":200,"columnId":300}}`, string(out))
+}
+
+func TestClient_CreateCellLinks(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `[
+			{"id":10,"cells":[
+				{"columnId":1,"value":null,"linkInFromCell":{"sheetId":100,"rowId":200,"columnId":300}},
+				{"columnId":2,"value":null,"linkInFromCell":{"sheetId":100,"rowId":200,"columnId":301}}
+			]},
+			{"id":11,"cells":[{"columnId":1,"value":null,"linkInFromCell":{"sheetId":100,"rowId":201,"columnId":300}}]}
+		]`, string(body))
+		_, _ = w.Write([]byte(`{"message":"SUCCESS","result":[{"id":10},{"id":11}]}`))
+	})
+	defer done()
+	rows, err := client.CreateCellLinks(1, []InboundLink{
+		{RowId: 10, ColumnId: 1, Source: CellLink{SheetId: 100, RowId: 200, ColumnId: 300}},
+		{RowId: 11, ColumnId: 1, Source: CellLink{SheetId: 100, RowId: 201, ColumnId: 300}},
+		{RowId: 10, ColumnId: 2, Source: CellLink{SheetId: 100, RowId: 200, ColumnId: 301}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, *rows, 2)
+
+	_, err = client.CreateCellLinks(1, []InboundLink{{RowId: 10, ColumnId: 1, Source: CellLink{SheetId: 100}}})
+	assert.Error(t, err)
+}
+
+func TestSheet_Links(t *testing.T) {
+	sheet := Sheet{Rows: []Row{
+		{Id: 10, Cells: []Cell{
+			{ColumnId: 1, LinkInFromCell: &CellLink{SheetId: 100, Status: CellLinkOK}},
+			{ColumnId: 2, LinksOutToCells: []CellLink{{SheetId: 200, Status: CellLinkBroken}, {SheetId: 201, Status: CellLinkOK}}},
+		}},
+		{Id: 11, Cells: []Cell{
+			{ColumnId: 1, LinkInFromCell: &CellLink{SheetId: 100, Status: CellLinkInaccessible}},
+		}},
+	}}
+	assert.Len(t, sheet.InboundLinks(), 2)
+	assert.Len(t, sheet.OutboundLinks(), 2)
+	broken := sheet.BrokenLinks()
+	assert.Len(t, broken, 2)
+	assert.Equal(t, int64(11), broken[0].RowId)
+	assert.True(t, broken[0].Inbound)
+	assert.Equal(t, int64(200), broken[1].Link.SheetId)
+}
@@ -22,7 +22,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
 	"time"
 )
 
@@ -55,8 +57,27 @@ type ResultObject struct {
 	Result      interface{}       `json:"result"`
 }
 
+type IndexResultObject struct {
+	PageInfo
+	Data []interface{} `json:"data"`
+}
+
+type PageInfo struct {
+	PageNumber int `json:"pageNumber"` //The current page in the full result set that the data array represents. NOTE: when a page number greater than totalPages is requested, the last page is instead returned.
+	PageSize   int `json:"pageSize"`   //The number of items in a page. Omitted if there is no limit to page size (and hence, all results are included). Unless otherwise specified, this defaults to 100 for most endpoints.
+	TotalCount int `json:"totalCount"` //The total number of items in the full result set.
+	TotalPages int `json:"totalPages"` //The total number of pages in the full result set.
+}
+
+// PageOptions controls pagination of list endpoints
+type PageOptions struct {
+	Page       int  // Which page to return. Defaults to 1
+	PageSize   int  // The maximum number of items to return per page. Defaults to 100
+	IncludeAll bool // If true, include all results, that is, do not paginate
+}
+
 type BulkItemFailure struct {
-	RowId int         `json:"rowId"` // The id of the Row that failed. Applicable only to bulk row operations
+	RowId int64       `json:"rowId"` // The id of the Row that failed. Applicable only to bulk row operations
 	Error ErrorObject `json:"error"` // The error caused by the failed item
 	Index int         `json:"index"` // The index of the failed item in the bulk request array
 }
@@ -107,6 +128,11 @@ func (c *Client) get(path string) (*http.Response, error) {
 }
 
 func (c *Client) do(method, path string, body io.Reader, headers *map[string]string) (*http.Response, error) {
+	resp, err := c.HTTPClient.Do(c.newRequest(method, path, body, headers))
+	return c.checkResponse(resp, err)
+}
+
+func (c *Client) newRequest(method, path string, body io.Reader, headers *map[string]string) *http.Request {
 	endpoint := c.APIEndpoint + path
 	req, _ := http.NewRequest(method, endpoint, body)
 	req.Header.Set("Accept", "application/json")
@@ -117,8 +143,20 @@ func (c *Client) do(method, path string, body io.Reader, headers *map[string]str
 			req.Header.Set(k, v)
 		}
 	}
-	resp, err := c.HTTPClient.Do(req)
-	return c.checkResponse(resp, err)
+	return req
+}
+
+// Return the undecoded JSON response, for callers that need every attribute the API returns
+func (c *Client) getRaw(path string) (json.RawMessage, error) {
+	var raw json.RawMessage
+	resp, err := c.get(path)
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &raw); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return raw, nil
 }
 
 func (c *Client) decodeJSON(resp *http.Response, payload interface{}) error {
@@ -150,6 +188,28 @@ func (c *Client) getErrorFromResponse(resp *http.Response) (*ErrorObject, error)
 	return &result, nil
 }
 
+func (p *PageOptions) setQuery(query url.Values) {
+	if p == nil {
+		return
+	}
+	if p.IncludeAll {
+		query.Set("includeAll", "true")
+	}
+	if p.Page > 0 {
+		query.Set("page", strconv.Itoa(p.Page))
+	}
+	if p.PageSize > 0 {
+		query.Set("pageSize", strconv.Itoa(p.PageSize))
+	}
+}
+
+func withQuery(path string, query url.Values) string {
+	if len(query) == 0 {
+		return path
+	}
+	return path + "?" + query.Encode()
+}
+
 func (c *ClientOptions) WithToken(t string) {
 	c.token = t
 }
@@ -18,10 +18,23 @@ package smartsheet
 
 import (
 	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 )
 
+// Point the client at a test server until the returned func is called
+func testServer(handler http.HandlerFunc) (*Client, func()) {
+	server := httptest.NewServer(handler)
+	endpoint := apiEndpoint
+	apiEndpoint = server.URL
+	return NewSmartsheetClient(&ClientOptions{token: "test"}), func() {
+		apiEndpoint = endpoint
+		server.Close()
+	}
+}
+
 func TestNewSmartsheetClientOptions(t *testing.T) {
 	os.Setenv("SMARTSHEET_ACCESS_TOKEN", "123")
 	options := ClientOptions{
@@ -18,54 +18,217 @@ package smartsheet
 
 import (
 	"fmt"
-	"github.com/mitchellh/mapstructure"
+	"net/url"
+	"strconv"
 )
 
 type Column struct {
-	Id               int64            `json:"id"`               // Column Id
-	SystemColumnType string           `json:"systemColumnType"` // When applicable, one of: AUTO_NUMBER, CREATED_BY, CREATED_DATE, MODIFIED_BY, MODIFIED_DATE. See System Columns.
-	Type             string           `json:"type"`             // Column type
-	AutoNumberFormat AutoNumberFormat `json:"autoNumberFormat"` // Present when systemColumnType == AUTO_NUMBER
-	ContactOptions   []ContactOption  `json:"contactOptions"`   // Array of ContactOption objects to specify a pre-defined list of values for the column. Column type must be CONTACT_LIST
-	Description      string           `json:"description"`      // Column description.
-	Format           string           `json:"format"`           // The format descriptor (see Formatting). Only returned if the include query string parameter contains format and this column has a non-default format applied to it.
-	Hidden           bool             `json:"hidden"`           // Indicates whether the column is hidden
-	Index            int64            `json:"index"`            // Column index or position. This number is zero-based.
-	Locked           bool             `json:"locked"`           // Indicates whether the column is locked. In a response, a value of true indicates that the column has been locked by the sheet owner or the admin.
-	LockedForUser    bool             `json:"lockedForUser"`    // Indicates whether the column is locked for the requesting user. This attribute may be present in a response, but cannot be specified in a request.
-	Options          []string         `json:"options"`          // Array of the options available for the column
-	Primary          bool             `json:"primary"`          // Returned only if the column is the Primary Column (value = true)
-	Symbol           string           `json:"symbol"`           // When applicable for CHECKBOX or PICKLIST column types. See Symbol Columns.
-	Tags             []string         `json:"tags"`             // Set of tags to indicate special columns. Each element in the array is set to one of the following values:
-	Title            string           `json:"title"`            // Column title
-	Validation       bool             `json:"validation"`       // Indicates whether validation has been enabled for the column (value = true)
-	Version          int              `json:"version"`          // Read only. The level of the column type. Each element in the array is set to one of the following values:
-	Width            int              `json:"width"`            // Display width of the column in pixels
+	Id               int64            `json:"id"`                // Column Id
+	SystemColumnType SystemColumnType `json:"systemColumnType"`  // When applicable, one of: AUTO_NUMBER, CREATED_BY, CREATED_DATE, MODIFIED_BY, MODIFIED_DATE. See System Columns.
+	Type             ColumnType       `json:"type"`              // Column type
+	AutoNumberFormat AutoNumberFormat `json:"autoNumberFormat"`  // Present when systemColumnType == AUTO_NUMBER
+	ContactOptions   []ContactOption  `json:"contactOptions"`    // Array of ContactOption objects to specify a pre-defined list of values for the column. Column type must be CONTACT_LIST
+	Description      string           `json:"description"`       // Column description.
+	Filter           *Filter          `json:"filter,omitempty"`  // The filter applied to the column. Only returned if the include query string parameter contains filters and the column has a filter applied to it.
+	Format           string           `json:"format"`            // The format descriptor (see Formatting). Only returned if the include query string parameter contains format and this column has a non-default format applied to it.
+	Formula          string           `json:"formula,omitempty"` // The formula for the column, if set, for instance =data@row.
+	Hidden           bool             `json:"hidden"`            // Indicates whether the column is hidden
+	Index            int64            `json:"index"`             // Column index or position. This number is zero-based.
+	Locked           bool             `json:"locked"`            // Indicates whether the column is locked. In a response, a value of true indicates that the column has been locked by the sheet owner or the admin.
+	LockedForUser    bool             `json:"lockedForUser"`     // Indicates whether the column is locked for the requesting user. This attribute may be present in a response, but cannot be specified in a request.
+	Options          []string         `json:"options"`           // Array of the options available for the column
+	Primary          bool             `json:"primary"`           // Returned only if the column is the Primary Column (value = true)
+	Symbol           Symbol           `json:"symbol"`            // When applicable for CHECKBOX or PICKLIST column types. See Symbol Columns.
+	Tags             []string         `json:"tags"`              // Set of tags to indicate special columns. Each element in the array is set to one of the following values:
+	Title            string           `json:"title"`             // Column title
+	Validation       bool             `json:"validation"`        // Indicates whether validation has been enabled for the column (value = true)
+	Version          int              `json:"version"`           // Read only. The level of the column type. Each element in the array is set to one of the following values:
+	Width            int              `json:"width"`             // Display width of the column in pixels
 }
 
-// Return ResultObject object
-func (c Client) GetColumnByName(sheetId int64, columnName string) (*Column, error) {
-	var res IndexResultObject
-	resp, err := c.get(fmt.Sprintf("%s/sheets/%d/columns", apiEndpoint, sheetId))
+// ColumnUpdate holds the column attributes to change. Nil attributes are left unchanged.
+type ColumnUpdate struct {
+	Title          *string          `json:"title,omitempty"`          // Column title
+	Type           ColumnType       `json:"type,omitempty"`           // Column type
+	Symbol         Symbol           `json:"symbol,omitempty"`         // When applicable for CHECKBOX or PICKLIST column types
+	Options        *[]string        `json:"options,omitempty"`        // Array of the options available for the column. Set to an empty array to remove the options
+	ContactOptions *[]ContactOption `json:"contactOptions,omitempty"` // Array of ContactOption objects. Column type must be CONTACT_LIST
+	Description    *string          `json:"description,omitempty"`    // Column description
+	Formula        *string          `json:"formula,omitempty"`        // Column formula. Set to an empty string to remove the formula
+	Hidden         *bool            `json:"hidden,omitempty"`         // Indicates whether the column is hidden
+	Index          *int64           `json:"index,omitempty"`          // Column index or position. This number is zero-based.
+	Locked         *bool            `json:"locked,omitempty"`         // Indicates whether the column is locked
+	Validation     *bool            `json:"validation,omitempty"`     // Indicates whether validation is enabled for the column
+	Width          *int             `json:"width,omitempty"`          // Display width of the column in pixels
+}
+
+// columnRequest is a Column as sent to create it, leaving out read only and unset attributes
+type columnRequest struct {
+	Title            string            `json:"title"`
+	Type             ColumnType        `json:"type"`
+	SystemColumnType SystemColumnType  `json:"systemColumnType,omitempty"`
+	AutoNumberFormat *AutoNumberFormat `json:"autoNumberFormat,omitempty"`
+	ContactOptions   []ContactOption   `json:"contactOptions,omitempty"`
+	Description      string            `json:"description,omitempty"`
+	Format           string            `json:"format,omitempty"`
+	Formula          string            `json:"formula,omitempty"`
+	Hidden           bool              `json:"hidden,omitempty"`
+	Index            *int64            `json:"index,omitempty"`
+	Locked           bool              `json:"locked,omitempty"`
+	Options          []string          `json:"options,omitempty"`
+	Primary          bool              `json:"primary,omitempty"`
+	Symbol           Symbol            `json:"symbol,omitempty"`
+	Validation       bool              `json:"validation,omitempty"`
+	Width            int               `json:"width,omitempty"`
+}
+
+// ListColumnsOptions controls which columns ListColumns returns
+type ListColumnsOptions struct {
+	PageOptions
+	IncludeFilters bool // Include the filter applied to each column
+	Level          int  // Column type level. 2 returns MULTI_CONTACT_LIST columns and 3 returns MULTI_PICKLIST columns, otherwise they are returned as TEXT_NUMBER
+}
+
+// ColumnPage is a page of columns returned by ListColumns
+type ColumnPage struct {
+	PageInfo
+	Data []Column `json:"data"`
+}
+
+// Return ColumnPage object
+func (c Client) ListColumns(sheetId int64, options *ListColumnsOptions) (*ColumnPage, error) {
+	var page ColumnPage
+	query := url.Values{}
+	if options != nil {
+		options.PageOptions.setQuery(query)
+		if options.IncludeFilters {
+			query.Set("include", "filters")
+		}
+		if options.Level > 0 {
+			query.Set("level", strconv.Itoa(options.Level))
+		}
+	}
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/sheets/%d/columns", apiEndpoint, sheetId), query))
 	if err != nil {
 		return nil, err
 	}
-	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+	if dErr := c.decodeJSON(resp, &page); dErr != nil {
 		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
 	}
-	for _, c := range res.Data {
-		var column Column
-		err = mapstructure.Decode(c, &column)
-		if err != nil {
-			return nil, fmt.Errorf("could not decode columns: %v", err)
-		}
-		if columnName == column.Title {
-			return &column, nil
+	return &page, nil
+}
+
+// Return Column object
+func (c Client) GetColumn(sheetId int64, columnId int64) (*Column, error) {
+	var column Column
+	resp, err := c.get(fmt.Sprintf("%s/sheets/%d/columns/%d", apiEndpoint, sheetId, columnId))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &column); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &column, nil
+}
+
+// Return Column object with title name
+func (c Client) GetColumnByName(sheetId int64, columnName string) (*Column, error) {
+	page, err := c.ListColumns(sheetId, &ListColumnsOptions{PageOptions: PageOptions{IncludeAll: true}})
+	if err != nil {
+		return nil, err
+	}
+	for i := range page.Data {
+		if columnName == page.Data[i].Title {
+			return &page.Data[i], nil
 		}
 	}
 	return nil, fmt.Errorf("cant find column by that name %s", columnName)
 }
 
+// Return the added Column objects. The columns are inserted together at index.
+func (c Client) AddColumns(sheetId int64, index int64, columns []Column) (*[]Column, error) {
+	payload := make([]columnRequest, len(columns))
+	for i := range columns {
+		if columns[i].Type == "" {
+			return nil, fmt.Errorf("column %s has no type", columns[i].Title)
+		}
+		if err := columns[i].validateTypes(); err != nil {
+			return nil, err
+		}
+		payload[i] = columns[i].request()
+		payload[i].Index = &index
+	}
+	var result []Column
+	res := ResultObject{Result: &result}
+	resp, err := c.post(fmt.Sprintf("%s/sheets/%d/columns", apiEndpoint, sheetId), payload, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &result, nil
+}
+
+// Return the updated Column object
+func (c Client) UpdateColumn(sheetId int64, columnId int64, update ColumnUpdate) (*Column, error) {
+	column := fmt.Sprintf("column id %d", columnId)
+	if update.Title != nil {
+		column = fmt.Sprintf("column %s", *update.Title)
+	}
+	if err := validateSymbol(column, update.Type, update.Symbol); err != nil {
+		return nil, err
+	}
+	var result Column
+	res := ResultObject{Result: &result}
+	resp, err := c.put(fmt.Sprintf("%s/sheets/%d/columns/%d", apiEndpoint, sheetId, columnId), update, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &result, nil
+}
+
+// Return ResultObject object
+func (c Client) DeleteColumn(sheetId int64, columnId int64) (*ResultObject, error) {
+	var res ResultObject
+	resp, err := c.delete(fmt.Sprintf("%s/sheets/%d/columns/%d", apiEndpoint, sheetId, columnId))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &res, nil
+}
+
+// Return the attributes of the column that can be sent to create it
+func (c Column) request() columnRequest {
+	request := columnRequest{
+		Title:            c.Title,
+		Type:             c.Type,
+		SystemColumnType: c.SystemColumnType,
+		ContactOptions:   c.ContactOptions,
+		Description:      c.Description,
+		Format:           c.Format,
+		Formula:          c.Formula,
+		Hidden:           c.Hidden,
+		Locked:           c.Locked,
+		Options:          c.Options,
+		Primary:          c.Primary,
+		Symbol:           c.Symbol,
+		Validation:       c.Validation,
+		Width:            c.Width,
+	}
+	if c.AutoNumberFormat != (AutoNumberFormat{}) {
+		format := c.AutoNumberFormat
+		request.AutoNumberFormat = &format
+	}
+	return request
+}
+
 type AutoNumberFormat struct {
 	Fill           string `json:"fill"`           // Indicates zero-padding. Must be between 0 and 10 "0" (zero) characters.
 	Prefix         string `json:"prefix"`         // The prefix. Can include the date tokens:
@@ -73,10 +236,14 @@ type AutoNumberFormat struct {
 	Suffix         string `json:"suffix"`         // The suffix. Can include the date tokens:
 }
 
-type IndexResultObject struct {
-	Data       []interface{} `json:"data"`
-	PageNumber int           `json:"pageNumber"` //The current page in the full result set that the data array represents. NOTE: when a page number greater than totalPages is requested, the last page is instead returned.
-	PageSize   int           `json:"pageSize"`   //The number of items in a page. Omitted if there is no limit to page size (and hence, all results are included). Unless otherwise specified, this defaults to 100 for most endpoints.
-	TotalCount int           `json:"totalCount"` //The total number of items in the full result set.
-	TotalPages int           `json:"totalPages"` //The total number of pages in the full result set.
+type Filter struct {
+	Type            string        `json:"type,omitempty"`            // One of LIST or CUSTOM
+	ExcludeSelected bool          `json:"excludeSelected,omitempty"` // If true, rows containing cells matching the values or criteria are excluded instead of included
+	Values          []interface{} `json:"values,omitempty"`          // Values to match. Only applicable to LIST filters
+	Criteria        []Criteria    `json:"criteria,omitempty"`        // Array of Criteria objects. Only applicable to CUSTOM filters
+}
+
+type Criteria struct {
+	Operator string        `json:"operator,omitempty"` // Condition operator, for instance EQUAL, CONTAINS or IS_BLANK
+	Values   []interface{} `json:"values,omitempty"`   // Values the operator is applied to
 }
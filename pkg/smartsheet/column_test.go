@@ -0,0 +1,118 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestClient_ListColumns(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sheets/1/columns", r.URL.Path)
+		assert.Equal(t, "filters", r.URL.Query().Get("include"))
+		assert.Equal(t, "2", r.URL.Query().Get("level"))
+		assert.Equal(t, "true", r.URL.Query().Get("includeAll"))
+		_, _ = w.Write([]byte(`{"pageNumber":1,"totalCount":2,"data":[
+			{"id":10,"title":"Task","type":"TEXT_NUMBER","primary":true},
+			{"id":11,"title":"Owner","type":"MULTI_CONTACT_LIST","filter":{"type":"LIST","values":["a@b.com"]}}
+		]}`))
+	})
+	defer done()
+	page, err := client.ListColumns(1, &ListColumnsOptions{
+		PageOptions:    PageOptions{IncludeAll: true},
+		IncludeFilters: true,
+		Level:          2,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.TotalCount)
+	assert.Equal(t, "Owner", page.Data[1].Title)
+	assert.Equal(t, "LIST", page.Data[1].Filter.Type)
+}
+
+func TestClient_AddColumns(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		var columns []map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&columns)
+		assert.Len(t, columns, 2)
+		for _, c := range columns {
+			assert.Equal(t, float64(0), c["index"])
+			assert.NotContains(t, c, "id")
+		}
+		_, _ = w.Write([]byte(`{"message":"SUCCESS","result":[{"id":20,"title":"A","index":0},{"id":21,"title":"B","index":1}]}`))
+	})
+	defer done()
+	columns, err := client.AddColumns(1, 0, []Column{{Title: "A", Type: "TEXT_NUMBER"}, {Title: "B", Type: "DATE"}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21), (*columns)[1].Id)
+}
+
+func TestClient_UpdateColumn(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		var update map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		assert.Equal(t, map[string]interface{}{"hidden": false, "title": "Renamed"}, update)
+		_, _ = w.Write([]byte(`{"message":"SUCCESS","result":{"id":20,"title":"Renamed"}}`))
+	})
+	defer done()
+	title, hidden := "Renamed", false
+	column, err := client.UpdateColumn(1, 20, ColumnUpdate{Title: &title, Hidden: &hidden})
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed", column.Title)
+}
+
+func TestClient_UpdateColumnClearOptions(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		var update map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&update)
+		assert.Equal(t, map[string]interface{}{"options": []interface{}{}}, update)
+		_, _ = w.Write([]byte(`{"message":"SUCCESS","result":{"id":20,"type":"PICKLIST","options":[]}}`))
+	})
+	defer done()
+	column, err := client.UpdateColumn(1, 20, ColumnUpdate{Options: &[]string{}})
+	assert.NoError(t, err)
+	assert.Empty(t, column.Options)
+}
+
+func TestColumn_MarshalZeroValues(t *testing.T) {
+	out, err := json.Marshal(Column{Title: "A", Type: ColumnTypeTextNumber})
+	assert.NoError(t, err)
+	var fields map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out, &fields))
+	assert.Equal(t, float64(0), fields["index"])
+	assert.Equal(t, false, fields["primary"])
+	assert.Contains(t, fields, "autoNumberFormat")
+}
+
+func TestClient_UpdateColumnSymbol(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"message":"SUCCESS","result":{"id":20,"type":"RATING","symbol":"HEARTS"}}`))
+	})
+	defer done()
+	_, err := client.UpdateColumn(1, 20, ColumnUpdate{Type: ColumnTypeCheckbox, Symbol: SymbolRYG})
+	assert.EqualError(t, err, "symbol RYG cannot be used with CHECKBOX column id 20")
+	title := "Status"
+	_, err = client.UpdateColumn(1, 20, ColumnUpdate{Title: &title, Type: ColumnTypeCheckbox, Symbol: SymbolRYG})
+	assert.EqualError(t, err, "symbol RYG cannot be used with CHECKBOX column Status")
+	// Values added to the API later are sent unchecked
+	column, err := client.UpdateColumn(1, 20, ColumnUpdate{Type: "RATING", Symbol: "HEARTS"})
+	assert.NoError(t, err)
+	assert.Equal(t, Symbol("HEARTS"), column.Symbol)
+}
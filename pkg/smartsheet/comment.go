@@ -16,14 +16,31 @@
 
 package smartsheet
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type Comment struct {
-	Id           int          // Comment Id
-	DiscussionId int          // (optional) 	Discussion Id
+	Id           int64        // Comment Id
+	DiscussionId int64        // (optional) 	Discussion Id
 	Attachments  []Attachment // Array of Attachment objects
 	CreatedAt    time.Time    // Time of creation
 	CreatedBy    User         // User object containing name and email of the comment's author
 	ModifiedAt   time.Time    // Time of last modification
 	Text         string       // Comment body
 }
+
+// Return the Comment object added to the discussion
+func (c Client) AddComment(sheetId int64, discussionId int64, text string) (*Comment, error) {
+	var comment Comment
+	res := ResultObject{Result: &comment}
+	resp, err := c.post(fmt.Sprintf("%s/sheets/%d/discussions/%d/comments", apiEndpoint, sheetId, discussionId), map[string]string{"text": text}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &comment, nil
+}
@@ -20,3 +20,9 @@ type ContactOption struct {
 	Email string `json:"email"` // A parsable email address.
 	Name  string `json:"name"`  // Can be a user's name, display name, or free text, such as a job class or TBD.
 }
+
+type Contact struct {
+	Email   string `json:"email,omitempty"`   // Email address of the contact
+	Name    string `json:"name,omitempty"`    // Name of the contact
+	ImageId string `json:"imageId,omitempty"` // Id of the contact's profile image
+}
@@ -16,13 +16,16 @@
 
 package smartsheet
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 type Discussion struct {
-	Id                 int          // Discussion Id
-	ParentId           int          // Id of the directly associated row or sheet: present only when the direct association is not clear (see List Discussions)
+	Id                 int64        // Discussion Id
+	ParentId           int64        // Id of the directly associated row or sheet: present only when the direct association is not clear (see List Discussions)
 	ParentType         string       // SHEET or ROW: present only when the direct association is not clear (see List Discussions)
-	AccessLevel        string       // User's permissions on the discussion
+	AccessLevel        AccessLevel  // User's permissions on the discussion
 	CommentAttachments []Attachment // Array of Attachment objects
 	CommentCount       int          // The number of comments in the discussion
 	Comments           []Comment    // Array of Comment objects
@@ -32,3 +35,26 @@ type Discussion struct {
 	ReadOnly           bool         // Indicates whether the user can modify the discussion
 	Title              string       // Read Only. Discussion title automatically created by duplicating the first 100 characters of the top-level comment
 }
+
+// Return the Discussion object created on the sheet with its first comment
+func (c Client) CreateDiscussion(sheetId int64, text string) (*Discussion, error) {
+	return c.createDiscussion(fmt.Sprintf("%s/sheets/%d/discussions", apiEndpoint, sheetId), text)
+}
+
+// Return the Discussion object created on the row with its first comment
+func (c Client) CreateRowDiscussion(sheetId int64, rowId int64, text string) (*Discussion, error) {
+	return c.createDiscussion(fmt.Sprintf("%s/sheets/%d/rows/%d/discussions", apiEndpoint, sheetId, rowId), text)
+}
+
+func (c Client) createDiscussion(path string, text string) (*Discussion, error) {
+	var discussion Discussion
+	res := ResultObject{Result: &discussion}
+	resp, err := c.post(path, map[string]interface{}{"comment": map[string]string{"text": text}}, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &discussion, nil
+}
@@ -0,0 +1,303 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import "fmt"
+
+// ColumnType is the type of a column. Values added to the API after this library was
+// written are kept as is, so they can be read and sent back unchanged.
+type ColumnType string
+
+const (
+	ColumnTypeAbstractDateTime ColumnType = "ABSTRACT_DATETIME"
+	ColumnTypeCheckbox         ColumnType = "CHECKBOX"
+	ColumnTypeContactList      ColumnType = "CONTACT_LIST"
+	ColumnTypeDate             ColumnType = "DATE"
+	ColumnTypeDateTime         ColumnType = "DATETIME"
+	ColumnTypeDuration         ColumnType = "DURATION"
+	ColumnTypeMultiContactList ColumnType = "MULTI_CONTACT_LIST"
+	ColumnTypeMultiPicklist    ColumnType = "MULTI_PICKLIST"
+	ColumnTypePicklist         ColumnType = "PICKLIST"
+	ColumnTypePredecessor      ColumnType = "PREDECESSOR"
+	ColumnTypeTextNumber       ColumnType = "TEXT_NUMBER"
+)
+
+// SystemColumnType marks a column whose values are maintained by Smartsheet
+type SystemColumnType string
+
+const (
+	SystemColumnAutoNumber   SystemColumnType = "AUTO_NUMBER"
+	SystemColumnCreatedBy    SystemColumnType = "CREATED_BY"
+	SystemColumnCreatedDate  SystemColumnType = "CREATED_DATE"
+	SystemColumnModifiedBy   SystemColumnType = "MODIFIED_BY"
+	SystemColumnModifiedDate SystemColumnType = "MODIFIED_DATE"
+)
+
+// AccessLevel is a user's permissions on a sheet, workspace, report, Sight or template
+type AccessLevel string
+
+const (
+	AccessLevelAdmin       AccessLevel = "ADMIN"
+	AccessLevelCommenter   AccessLevel = "COMMENTER"
+	AccessLevelEditor      AccessLevel = "EDITOR"
+	AccessLevelEditorShare AccessLevel = "EDITOR_SHARE"
+	AccessLevelOwner       AccessLevel = "OWNER"
+	AccessLevelViewer      AccessLevel = "VIEWER"
+)
+
+// Symbol is the symbol set displayed by a CHECKBOX or PICKLIST column
+type Symbol string
+
+const (
+	// CHECKBOX symbols
+	SymbolFlag Symbol = "FLAG"
+	SymbolStar Symbol = "STAR"
+
+	// PICKLIST symbols
+	SymbolArrows3Way      Symbol = "ARROWS_3_WAY"
+	SymbolArrows4Way      Symbol = "ARROWS_4_WAY"
+	SymbolArrows5Way      Symbol = "ARROWS_5_WAY"
+	SymbolDecisionShapes  Symbol = "DECISION_SHAPES"
+	SymbolDecisionSymbols Symbol = "DECISION_SYMBOLS"
+	SymbolDirections3Way  Symbol = "DIRECTIONS_3_WAY"
+	SymbolDirections4Way  Symbol = "DIRECTIONS_4_WAY"
+	SymbolEffort          Symbol = "EFFORT"
+	SymbolHarveyBalls     Symbol = "HARVEY_BALLS"
+	SymbolHearts          Symbol = "HEARTS"
+	SymbolMoney           Symbol = "MONEY"
+	SymbolPain            Symbol = "PAIN"
+	SymbolPriority        Symbol = "PRIORITY"
+	SymbolPriorityHML     Symbol = "PRIORITY_HML"
+	SymbolProgress        Symbol = "PROGRESS"
+	SymbolRYG             Symbol = "RYG"
+	SymbolRYGB            Symbol = "RYGB"
+	SymbolRYGG            Symbol = "RYGG"
+	SymbolSignal          Symbol = "SIGNAL"
+	SymbolSkiSymbols      Symbol = "SKI"
+	SymbolStarRating      Symbol = "STAR_RATING"
+	SymbolVCR             Symbol = "VCR"
+	SymbolWeather         Symbol = "WEATHER"
+)
+
+// CellLinkStatus is the state of a cell link
+type CellLinkStatus string
+
+const (
+	CellLinkOK           CellLinkStatus = "OK"
+	CellLinkBlocked      CellLinkStatus = "BLOCKED"
+	CellLinkBroken       CellLinkStatus = "BROKEN"
+	CellLinkCircular     CellLinkStatus = "CIRCULAR"
+	CellLinkDisabled     CellLinkStatus = "DISABLED"
+	CellLinkInaccessible CellLinkStatus = "INACCESSIBLE"
+	CellLinkInvalid      CellLinkStatus = "INVALID"
+	CellLinkNotShared    CellLinkStatus = "NOT_SHARED"
+)
+
+// CopyInclude is an element copied along with a workspace, folder or sheet
+type CopyInclude string
+
+const (
+	CopyIncludeAll            CopyInclude = "all"
+	CopyIncludeAttachments    CopyInclude = "attachments"
+	CopyIncludeBrand          CopyInclude = "brand"
+	CopyIncludeCellLinks      CopyInclude = "cellLinks"
+	CopyIncludeData           CopyInclude = "data"
+	CopyIncludeDiscussions    CopyInclude = "discussions"
+	CopyIncludeFilters        CopyInclude = "filters"
+	CopyIncludeForms          CopyInclude = "forms"
+	CopyIncludeRuleRecipients CopyInclude = "ruleRecipients"
+	CopyIncludeRules          CopyInclude = "rules"
+	CopyIncludeShares         CopyInclude = "shares"
+)
+
+// SkipRemap is a kind of reference left pointing at the original objects when copying
+type SkipRemap string
+
+const (
+	SkipRemapCellLinks       SkipRemap = "cellLinks"
+	SkipRemapReports         SkipRemap = "reports"
+	SkipRemapSheetHyperlinks SkipRemap = "sheetHyperlinks"
+	SkipRemapSights          SkipRemap = "sights"
+)
+
+// DestinationType is the kind of container a copy or move goes to
+type DestinationType string
+
+const (
+	DestinationFolder    DestinationType = "folder"
+	DestinationHome      DestinationType = "home"
+	DestinationWorkspace DestinationType = "workspace"
+)
+
+// ItemType is the kind of a Home, workspace or folder item, or of a search result
+type ItemType string
+
+const (
+	ItemFolder    ItemType = "folder"
+	ItemReport    ItemType = "report"
+	ItemSheet     ItemType = "sheet"
+	ItemSight     ItemType = "sight"
+	ItemTemplate  ItemType = "template"
+	ItemWorkspace ItemType = "workspace"
+
+	// Search results only
+	ItemAttachment   ItemType = "attachment"
+	ItemDiscussion   ItemType = "discussion"
+	ItemRow          ItemType = "row"
+	ItemSummaryField ItemType = "summaryField"
+)
+
+// SearchScope restricts a search to some kinds of content
+type SearchScope string
+
+const (
+	SearchAttachments    SearchScope = "attachments"
+	SearchCellData       SearchScope = "cellData"
+	SearchComments       SearchScope = "comments"
+	SearchFolderNames    SearchScope = "folderNames"
+	SearchReportNames    SearchScope = "reportNames"
+	SearchSheetNames     SearchScope = "sheetNames"
+	SearchSightNames     SearchScope = "sightNames"
+	SearchSummaryFields  SearchScope = "summaryFields"
+	SearchTemplateNames  SearchScope = "templateNames"
+	SearchWorkspaceNames SearchScope = "workspaceNames"
+)
+
+// WidgetType is the kind of a Sight widget, which decides the type of its contents
+type WidgetType string
+
+const (
+	WidgetChart        WidgetType = "CHART"
+	WidgetGridGantt    WidgetType = "GRIDGANTT" // A report
+	WidgetImage        WidgetType = "IMAGE"
+	WidgetMetric       WidgetType = "METRIC"
+	WidgetRichText     WidgetType = "RICHTEXT"
+	WidgetSheetSummary WidgetType = "SHEETSUMMARY"
+	WidgetShortcut     WidgetType = "SHORTCUT"
+	WidgetShortcutIcon WidgetType = "SHORTCUTICON"
+	WidgetShortcutList WidgetType = "SHORTCUTLIST"
+	WidgetTitle        WidgetType = "TITLE"
+	WidgetWebContent   WidgetType = "WEBCONTENT"
+)
+
+var columnTypes = map[ColumnType]bool{
+	ColumnTypeAbstractDateTime: true,
+	ColumnTypeCheckbox:         true,
+	ColumnTypeContactList:      true,
+	ColumnTypeDate:             true,
+	ColumnTypeDateTime:         true,
+	ColumnTypeDuration:         true,
+	ColumnTypeMultiContactList: true,
+	ColumnTypeMultiPicklist:    true,
+	ColumnTypePicklist:         true,
+	ColumnTypePredecessor:      true,
+	ColumnTypeTextNumber:       true,
+}
+
+var systemColumnTypes = map[SystemColumnType]bool{
+	SystemColumnAutoNumber:   true,
+	SystemColumnCreatedBy:    true,
+	SystemColumnCreatedDate:  true,
+	SystemColumnModifiedBy:   true,
+	SystemColumnModifiedDate: true,
+}
+
+var accessLevels = map[AccessLevel]bool{
+	AccessLevelAdmin:       true,
+	AccessLevelCommenter:   true,
+	AccessLevelEditor:      true,
+	AccessLevelEditorShare: true,
+	AccessLevelOwner:       true,
+	AccessLevelViewer:      true,
+}
+
+var checkboxSymbols = map[Symbol]bool{
+	SymbolFlag: true,
+	SymbolStar: true,
+}
+
+var picklistSymbols = map[Symbol]bool{
+	SymbolArrows3Way:      true,
+	SymbolArrows4Way:      true,
+	SymbolArrows5Way:      true,
+	SymbolDecisionShapes:  true,
+	SymbolDecisionSymbols: true,
+	SymbolDirections3Way:  true,
+	SymbolDirections4Way:  true,
+	SymbolEffort:          true,
+	SymbolHarveyBalls:     true,
+	SymbolHearts:          true,
+	SymbolMoney:           true,
+	SymbolPain:            true,
+	SymbolPriority:        true,
+	SymbolPriorityHML:     true,
+	SymbolProgress:        true,
+	SymbolRYG:             true,
+	SymbolRYGB:            true,
+	SymbolRYGG:            true,
+	SymbolSignal:          true,
+	SymbolSkiSymbols:      true,
+	SymbolStarRating:      true,
+	SymbolVCR:             true,
+	SymbolWeather:         true,
+}
+
+// Return true if the column type is one of the known types
+func (t ColumnType) IsKnown() bool {
+	return columnTypes[t]
+}
+
+// Return true for DATE, DATETIME and ABSTRACT_DATETIME columns
+func (t ColumnType) IsDate() bool {
+	return t == ColumnTypeDate || t == ColumnTypeDateTime || t == ColumnTypeAbstractDateTime
+}
+
+// Return true if the system column type is one of the known types
+func (t SystemColumnType) IsKnown() bool {
+	return systemColumnTypes[t]
+}
+
+// Return true if the access level is one of the known levels
+func (l AccessLevel) IsKnown() bool {
+	return accessLevels[l]
+}
+
+// Return true if the symbol is one of the known symbols
+func (s Symbol) IsKnown() bool {
+	return checkboxSymbols[s] || picklistSymbols[s]
+}
+
+// Return true if the link no longer mirrors its source: BROKEN, BLOCKED or INACCESSIBLE
+func (s CellLinkStatus) IsBroken() bool {
+	return s == CellLinkBroken || s == CellLinkBlocked || s == CellLinkInaccessible
+}
+
+// Return an error if the column uses a known symbol that does not apply to its known type.
+// Types, system column types and symbols added to the API later are passed through unchecked.
+func (c Column) validateTypes() error {
+	return validateSymbol(fmt.Sprintf("column %s", c.Title), c.Type, c.Symbol)
+}
+
+func validateSymbol(column string, t ColumnType, s Symbol) error {
+	if !s.IsKnown() || !t.IsKnown() {
+		return nil
+	}
+	if (t == ColumnTypeCheckbox && checkboxSymbols[s]) || (t == ColumnTypePicklist && picklistSymbols[s]) {
+		return nil
+	}
+	return fmt.Errorf("symbol %s cannot be used with %s %s", s, t, column)
+}
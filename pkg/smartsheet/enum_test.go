@@ -0,0 +1,59 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestColumnType_RoundTrip(t *testing.T) {
+	data := `{"id":1,"type":"FUTURE_TYPE","symbol":"FUTURE_SYMBOL","title":"A"}`
+	var column Column
+	assert.NoError(t, json.Unmarshal([]byte(data), &column))
+	assert.False(t, column.Type.IsKnown())
+	out, err := json.Marshal(column)
+	assert.NoError(t, err)
+	var fields map[string]interface{}
+	assert.NoError(t, json.Unmarshal(out, &fields))
+	assert.Equal(t, "FUTURE_TYPE", fields["type"])
+	assert.Equal(t, "FUTURE_SYMBOL", fields["symbol"])
+}
+
+func TestColumn_validateTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		column  Column
+		wantErr bool
+	}{
+		{"known", Column{Type: ColumnTypePicklist, Symbol: SymbolRYG}, false},
+		{"system", Column{Type: ColumnTypeTextNumber, SystemColumnType: SystemColumnAutoNumber}, false},
+		{"unknown type", Column{Type: "TEXT"}, false},
+		{"unknown system type", Column{Type: ColumnTypeDate, SystemColumnType: "DELETED_DATE"}, false},
+		{"unknown symbol", Column{Type: ColumnTypePicklist, Symbol: "SMILEYS"}, false},
+		{"known symbol for unknown type", Column{Type: "RATING", Symbol: SymbolRYG}, false},
+		{"symbol for other type", Column{Type: ColumnTypeCheckbox, Symbol: SymbolRYG}, true},
+		{"symbol for text column", Column{Type: ColumnTypeTextNumber, Symbol: SymbolFlag}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.column.validateTypes()
+			assert.Equal(t, tt.wantErr, err != nil)
+		})
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"net/url"
+)
+
+type Favorite struct {
+	Type     ItemType `json:"type"`     // One of folder, report, sheet, sight, template or workspace
+	ObjectId int64    `json:"objectId"` // Id of the favorite item
+}
+
+// FavoritePage is a page of favorites returned by ListFavorites
+type FavoritePage struct {
+	PageInfo
+	Data []Favorite `json:"data"`
+}
+
+// Return FavoritePage object with the items the user has marked as favorites
+func (c Client) ListFavorites(options *PageOptions) (*FavoritePage, error) {
+	var page FavoritePage
+	query := url.Values{}
+	options.setQuery(query)
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/favorites", apiEndpoint), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &page); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &page, nil
+}
+
+// Return the added Favorite objects
+func (c Client) AddFavorites(favorites []Favorite) (*[]Favorite, error) {
+	var added []Favorite
+	res := ResultObject{Result: &added}
+	resp, err := c.post(fmt.Sprintf("%s/favorites", apiEndpoint), favorites, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &added, nil
+}
+
+// Return ResultObject object. Items of the given type are removed from the user's favorites.
+func (c Client) RemoveFavorites(itemType ItemType, objectIds []int64) (*ResultObject, error) {
+	if len(objectIds) == 0 {
+		return nil, fmt.Errorf("no %s ids to remove from favorites", itemType)
+	}
+	var res ResultObject
+	query := url.Values{}
+	query.Set("objectIds", joinIds(objectIds))
+	resp, err := c.delete(withQuery(fmt.Sprintf("%s/favorites/%s", apiEndpoint, itemType), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &res, nil
+}
@@ -0,0 +1,150 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type Folder struct {
+	Id        int64      `json:"id"`        // Folder Id
+	Favorite  bool       `json:"favorite"`  // Returned only if the user has marked the folder as a favorite in their "Home" tab (value = true)
+	Folders   []Folder   `json:"folders"`   // Array of Folder objects
+	Name      string     `json:"name"`      // Folder name
+	Permalink string     `json:"permalink"` // URL that represents a direct link to the folder in Smartsheet
+	Reports   []Report   `json:"reports"`   // Array of Report objects
+	Sheets    []Sheet    `json:"sheets"`    // Array of Sheet objects
+	Sights    []Sight    `json:"sights"`    // Array of Sight objects
+	Templates []Template `json:"templates"` // Array of Template objects
+}
+
+// FolderPage is a page of folders returned by ListFolders
+type FolderPage struct {
+	PageInfo
+	Data []Folder `json:"data"`
+}
+
+// Return Folder object with its sheets, reports, Sights, templates and subfolders.
+// include may contain ownerInfo, sheetVersion or source.
+func (c Client) GetFolder(folderId int64, include []string) (*Folder, error) {
+	var folder Folder
+	query := url.Values{}
+	if len(include) > 0 {
+		query.Set("include", strings.Join(include, ","))
+	}
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/folders/%d", apiEndpoint, folderId), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &folder); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &folder, nil
+}
+
+// Return FolderPage object with the subfolders of a folder
+func (c Client) ListFolders(folderId int64, options *PageOptions) (*FolderPage, error) {
+	var page FolderPage
+	query := url.Values{}
+	options.setQuery(query)
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/folders/%d/folders", apiEndpoint, folderId), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &page); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &page, nil
+}
+
+// Return the Folder object created in Home
+func (c Client) CreateFolder(name string) (*Folder, error) {
+	return c.createFolder(fmt.Sprintf("%s/home/folders", apiEndpoint), name)
+}
+
+// Return the Folder object created in a folder
+func (c Client) CreateFolderInFolder(folderId int64, name string) (*Folder, error) {
+	return c.createFolder(fmt.Sprintf("%s/folders/%d/folders", apiEndpoint, folderId), name)
+}
+
+// Return the Folder object created in a workspace
+func (c Client) CreateFolderInWorkspace(workspaceId int64, name string) (*Folder, error) {
+	return c.createFolder(fmt.Sprintf("%s/workspaces/%d/folders", apiEndpoint, workspaceId), name)
+}
+
+// Return the renamed Folder object
+func (c Client) UpdateFolder(folderId int64, name string) (*Folder, error) {
+	resp, err := c.put(fmt.Sprintf("%s/folders/%d", apiEndpoint, folderId), map[string]string{"name": name}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeFolder(resp)
+}
+
+// Return ResultObject object
+func (c Client) DeleteFolder(folderId int64) (*ResultObject, error) {
+	var res ResultObject
+	resp, err := c.delete(fmt.Sprintf("%s/folders/%d", apiEndpoint, folderId))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &res, nil
+}
+
+// Return the new Folder object. Only its id, name and permalink are set;
+// use GetFolder to get the ids of the copied contents.
+func (c Client) CopyFolder(folderId int64, destination ContainerDestination, options *CopyOptions) (*Folder, error) {
+	path := withQuery(fmt.Sprintf("%s/folders/%d/copy", apiEndpoint, folderId), options.query())
+	resp, err := c.post(path, destination, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeFolder(resp)
+}
+
+// Return the moved Folder object. The destination's NewName is ignored.
+func (c Client) MoveFolder(folderId int64, destination ContainerDestination) (*Folder, error) {
+	destination.NewName = ""
+	resp, err := c.post(fmt.Sprintf("%s/folders/%d/move", apiEndpoint, folderId), destination, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeFolder(resp)
+}
+
+func (c Client) createFolder(path string, name string) (*Folder, error) {
+	resp, err := c.post(path, map[string]string{"name": name}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeFolder(resp)
+}
+
+func (c Client) decodeFolder(resp *http.Response) (*Folder, error) {
+	var folder Folder
+	res := ResultObject{Result: &folder}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &folder, nil
+}
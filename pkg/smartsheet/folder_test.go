@@ -0,0 +1,120 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestClient_GetFolder(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/folders/1", r.URL.Path)
+		assert.Equal(t, "source", r.URL.Query().Get("include"))
+		_, _ = w.Write([]byte(`{"id":1,"name":"Plans","folders":[{"id":2,"name":"Archive"}],"sheets":[{"id":3,"name":"Plan"}],"sights":[{"id":4,"name":"Status"}],"templates":[{"id":5,"name":"Plan template"}],"reports":[{}]}`))
+	})
+	defer done()
+	folder, err := client.GetFolder(1, []string{"source"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), folder.Folders[0].Id)
+	assert.Equal(t, "Plan", folder.Sheets[0].Name)
+	assert.Len(t, folder.Sights, 1)
+	assert.Len(t, folder.Templates, 1)
+	assert.Len(t, folder.Reports, 1)
+}
+
+func TestClient_ListFolders(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/folders/1/folders", r.URL.Path)
+		assert.Equal(t, "50", r.URL.Query().Get("pageSize"))
+		_, _ = w.Write([]byte(`{"pageNumber":1,"pageSize":50,"totalPages":1,"totalCount":2,"data":[{"id":2,"name":"A"},{"id":3,"name":"B"}]}`))
+	})
+	defer done()
+	page, err := client.ListFolders(1, &PageOptions{PageSize: 50})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, page.TotalCount)
+	assert.Equal(t, "B", page.Data[1].Name)
+}
+
+func TestClient_CreateFolder(t *testing.T) {
+	var paths []string
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		paths = append(paths, r.URL.Path)
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]string{"name": "Plans"}, body)
+		_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":9,"name":"Plans"}}`))
+	})
+	defer done()
+	_, err := client.CreateFolder("Plans")
+	assert.NoError(t, err)
+	_, err = client.CreateFolderInWorkspace(1, "Plans")
+	assert.NoError(t, err)
+	folder, err := client.CreateFolderInFolder(2, "Plans")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9), folder.Id)
+	assert.Equal(t, []string{"/home/folders", "/workspaces/1/folders", "/folders/2/folders"}, paths)
+}
+
+func TestClient_UpdateDeleteFolder(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/folders/1", r.URL.Path)
+		switch r.Method {
+		case "PUT":
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":1,"name":"Renamed"}}`))
+		case "DELETE":
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		}
+	})
+	defer done()
+	folder, err := client.UpdateFolder(1, "Renamed")
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed", folder.Name)
+	res, err := client.DeleteFolder(1)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, res.ResultCode)
+}
+
+func TestClient_CopyMoveFolder(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		switch r.URL.Path {
+		case "/folders/1/copy":
+			assert.Equal(t, "all", r.URL.Query().Get("include"))
+			assert.Equal(t, "cellLinks", r.URL.Query().Get("skipRemap"))
+			assert.Equal(t, map[string]interface{}{"destinationType": "workspace", "destinationId": 5.0, "newName": "Copy"}, body)
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":2,"name":"Copy"}}`))
+		case "/folders/1/move":
+			assert.Equal(t, map[string]interface{}{"destinationType": "workspace", "destinationId": 5.0}, body)
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":1,"name":"Plans"}}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer done()
+	destination := ContainerDestination{DestinationType: DestinationWorkspace, DestinationId: 5, NewName: "Copy"}
+	folder, err := client.CopyFolder(1, destination, &CopyOptions{Include: []CopyInclude{CopyIncludeAll}, SkipRemap: []SkipRemap{SkipRemapCellLinks}})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), folder.Id)
+	folder, err = client.MoveFolder(1, destination)
+	assert.NoError(t, err)
+	assert.Equal(t, "Plans", folder.Name)
+}
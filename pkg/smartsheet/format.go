@@ -0,0 +1,206 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Number of positions in a format descriptor
+const formatFields = 17
+
+// Horizontal alignment indexes, as listed in FormatTables.HorizontalAlign
+const (
+	AlignDefault = 0
+	AlignLeft    = 1
+	AlignCenter  = 2
+	AlignRight   = 3
+)
+
+// Vertical alignment indexes, as listed in FormatTables.VerticalAlign
+const (
+	VerticalAlignDefault = 0
+	VerticalAlignTop     = 1
+	VerticalAlignMiddle  = 2
+	VerticalAlignBottom  = 3
+)
+
+// Number format indexes, as listed in FormatTables.NumberFormat
+const (
+	NumberFormatNone     = 0
+	NumberFormatNumber   = 1
+	NumberFormatCurrency = 2
+	NumberFormatPercent  = 3
+)
+
+// Format is a parsed format descriptor, as used by Cell.Format, Row.Format, Column.Format and SummaryField.Format.
+// Nil attributes are left unset in the descriptor, so the object inherits them. Colors, fonts, font sizes,
+// currencies and date formats are indexes into the FormatTables returned by GetServerInfo.
+type Format struct {
+	FontFamily         *int  // Index into FormatTables.FontFamily
+	FontSize           *int  // Index into FormatTables.FontSize
+	Bold               *bool // Bold text
+	Italic             *bool // Italic text
+	Underline          *bool // Underlined text
+	Strikethrough      *bool // Struck through text
+	HorizontalAlign    *int  // One of the Align constants
+	VerticalAlign      *int  // One of the VerticalAlign constants
+	Color              *int  // Text color. Index into FormatTables.Color
+	BackgroundColor    *int  // Background color. Index into FormatTables.Color
+	TaskbarColor       *int  // Gantt taskbar color. Index into FormatTables.Color
+	Currency           *int  // Index into FormatTables.Currency
+	DecimalCount       *int  // Number of decimal places
+	ThousandsSeparator *bool // Show a thousands separator
+	NumberFormat       *int  // One of the NumberFormat constants
+	TextWrap           *bool // Wrap text
+	DateFormat         *int  // Index into FormatTables.DateFormat
+	extra              []string
+}
+
+// Parse a format descriptor such as ",,1,,,,,,,3,,,,,,,"
+func ParseFormat(descriptor string) (Format, error) {
+	var f Format
+	if descriptor == "" {
+		return f, nil
+	}
+	fields := strings.Split(descriptor, ",")
+	ints := []**int{&f.FontFamily, &f.FontSize, nil, nil, nil, nil, &f.HorizontalAlign, &f.VerticalAlign,
+		&f.Color, &f.BackgroundColor, &f.TaskbarColor, &f.Currency, &f.DecimalCount, nil, &f.NumberFormat, nil, &f.DateFormat}
+	bools := map[int]**bool{2: &f.Bold, 3: &f.Italic, 4: &f.Underline, 5: &f.Strikethrough, 13: &f.ThousandsSeparator, 15: &f.TextWrap}
+	for i, field := range fields {
+		if i >= formatFields {
+			// Keep positions added to the descriptor after this library was written
+			f.extra = append([]string(nil), fields[formatFields:]...)
+			break
+		}
+		if field == "" {
+			continue
+		}
+		n, err := strconv.Atoi(field)
+		if err != nil || n < 0 {
+			return Format{}, fmt.Errorf("invalid format descriptor %q: position %d is %q", descriptor, i, field)
+		}
+		if b, ok := bools[i]; ok {
+			value := n == 1
+			*b = &value
+			continue
+		}
+		value := n
+		*ints[i] = &value
+	}
+	return f, nil
+}
+
+// Return the format descriptor
+func (f Format) String() string {
+	fields := []string{
+		formatInt(f.FontFamily), formatInt(f.FontSize), formatBool(f.Bold), formatBool(f.Italic),
+		formatBool(f.Underline), formatBool(f.Strikethrough), formatInt(f.HorizontalAlign), formatInt(f.VerticalAlign),
+		formatInt(f.Color), formatInt(f.BackgroundColor), formatInt(f.TaskbarColor), formatInt(f.Currency),
+		formatInt(f.DecimalCount), formatBool(f.ThousandsSeparator), formatInt(f.NumberFormat), formatBool(f.TextWrap),
+		formatInt(f.DateFormat),
+	}
+	return strings.Join(append(fields, f.extra...), ",")
+}
+
+// Return a copy of the format with the attributes set in other applied on top
+func (f Format) Merge(other Format) Format {
+	ints := [][2]**int{{&f.FontFamily, &other.FontFamily}, {&f.FontSize, &other.FontSize},
+		{&f.HorizontalAlign, &other.HorizontalAlign}, {&f.VerticalAlign, &other.VerticalAlign}, {&f.Color, &other.Color},
+		{&f.BackgroundColor, &other.BackgroundColor}, {&f.TaskbarColor, &other.TaskbarColor}, {&f.Currency, &other.Currency},
+		{&f.DecimalCount, &other.DecimalCount}, {&f.NumberFormat, &other.NumberFormat}, {&f.DateFormat, &other.DateFormat}}
+	for _, pair := range ints {
+		if *pair[1] != nil {
+			*pair[0] = *pair[1]
+		}
+	}
+	bools := [][2]**bool{{&f.Bold, &other.Bold}, {&f.Italic, &other.Italic}, {&f.Underline, &other.Underline},
+		{&f.Strikethrough, &other.Strikethrough}, {&f.ThousandsSeparator, &other.ThousandsSeparator}, {&f.TextWrap, &other.TextWrap}}
+	for _, pair := range bools {
+		if *pair[1] != nil {
+			*pair[0] = *pair[1]
+		}
+	}
+	return f
+}
+
+// Return a copy of the format with bold text
+func (f Format) WithBold(bold bool) Format {
+	f.Bold = &bold
+	return f
+}
+
+// Return a copy of the format with italic text
+func (f Format) WithItalic(italic bool) Format {
+	f.Italic = &italic
+	return f
+}
+
+// Return a copy of the format with the text color set to the FormatTables.Color index
+func (f Format) WithColor(index int) Format {
+	f.Color = &index
+	return f
+}
+
+// Return a copy of the format with the background color set to the FormatTables.Color index
+func (f Format) WithBackgroundColor(index int) Format {
+	f.BackgroundColor = &index
+	return f
+}
+
+// Return a copy of the format with the horizontal alignment set to one of the Align constants
+func (f Format) WithHorizontalAlign(align int) Format {
+	f.HorizontalAlign = &align
+	return f
+}
+
+// Return a copy of the format with the number format set to one of the NumberFormat constants
+func (f Format) WithNumberFormat(format int) Format {
+	f.NumberFormat = &format
+	return f
+}
+
+// Return a copy of the format with the given number of decimal places
+func (f Format) WithDecimalCount(count int) Format {
+	f.DecimalCount = &count
+	return f
+}
+
+// Return a copy of the format with the date format set to the FormatTables.DateFormat index
+func (f Format) WithDateFormat(index int) Format {
+	f.DateFormat = &index
+	return f
+}
+
+func formatInt(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
+
+func formatBool(b *bool) string {
+	switch {
+	case b == nil:
+		return ""
+	case *b:
+		return "1"
+	}
+	return "0"
+}
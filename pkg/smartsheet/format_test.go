@@ -0,0 +1,99 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	tests := []struct {
+		name       string
+		descriptor string
+		wantErr    bool
+	}{
+		{"empty", "", false},
+		{"unset", ",,,,,,,,,,,,,,,,", false},
+		{"bold background", ",,1,,,,,,,22,,,,,,,", false},
+		{"full", "0,2,1,1,0,0,2,1,3,22,4,13,2,1,2,1,5", false},
+		{"future positions", ",,1,,,,,,,,,,,,,,,7,8", false},
+		{"not a number", ",,x,,,,,,,,,,,,,,", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFormat(tt.descriptor)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			if tt.descriptor != "" {
+				assert.Equal(t, tt.descriptor, f.String())
+			}
+		})
+	}
+}
+
+func TestFormat_Fields(t *testing.T) {
+	f, err := ParseFormat("0,2,1,1,0,0,2,1,3,22,4,13,2,1,2,1,5")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *f.FontSize)
+	assert.True(t, *f.Bold)
+	assert.False(t, *f.Underline)
+	assert.Equal(t, AlignCenter, *f.HorizontalAlign)
+	assert.Equal(t, 22, *f.BackgroundColor)
+	assert.Equal(t, NumberFormatCurrency, *f.NumberFormat)
+	assert.Equal(t, 5, *f.DateFormat)
+
+	flagged := Format{}.WithBold(true).WithBackgroundColor(22)
+	assert.Equal(t, ",,1,,,,,,,22,,,,,,,", flagged.String())
+
+	merged := f.Merge(Format{}.WithBold(false).WithColor(1))
+	assert.False(t, *merged.Bold)
+	assert.Equal(t, 1, *merged.Color)
+	assert.Equal(t, 22, *merged.BackgroundColor)
+	assert.True(t, *f.Bold)
+}
+
+func TestFormatTables_Lookups(t *testing.T) {
+	tables := FormatTables{
+		Color:      []string{"none", "#000000", "#FF0000"},
+		FontFamily: []FontFamily{{Name: "Arial"}, {Name: "Tahoma"}},
+		Currency:   []Currency{{Code: "USD", Symbol: "$"}, {Code: "EUR", Symbol: "€"}},
+		DateFormat: []string{"LOCALE_BASED", "MMMM_D_YYYY"},
+	}
+	red, err := tables.ColorIndex("#ff0000")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, red)
+	_, err = tables.ColorIndex("#123456")
+	assert.Error(t, err)
+	font, err := tables.FontFamilyIndex("tahoma")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, font)
+	eur, err := tables.CurrencyIndex("EUR")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, eur)
+
+	color, background, taskbar, err := tables.Colors(Format{}.WithColor(1).WithBackgroundColor(red))
+	assert.NoError(t, err)
+	assert.Equal(t, "#000000", color)
+	assert.Equal(t, "#FF0000", background)
+	assert.Equal(t, "", taskbar)
+	_, _, _, err = tables.Colors(Format{}.WithColor(9))
+	assert.Error(t, err)
+}
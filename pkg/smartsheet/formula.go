@@ -0,0 +1,274 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FormulaExpr is a piece of a formula. Build one from references, literals and function calls,
+// then use Formula or Sheet.Formula to get the text for Cell.Formula or Column.Formula.
+type FormulaExpr struct {
+	text string
+	err  error
+}
+
+// Reference to the cell of a column in the current row: [Col]@row
+func ColumnRef(column string) FormulaExpr {
+	return FormulaExpr{text: columnName(column) + "@row"}
+}
+
+// Reference to the cell of a column in a given row number: [Col]5
+func CellRef(column string, rowNumber int) FormulaExpr {
+	if rowNumber < 1 {
+		return FormulaExpr{err: fmt.Errorf("invalid row number %d for column %s", rowNumber, column)}
+	}
+	return FormulaExpr{text: fmt.Sprintf("%s%d", columnName(column), rowNumber)}
+}
+
+// Reference to every cell of one or more adjacent columns: [From]:[To]
+func ColumnRange(from string, to string) FormulaExpr {
+	return FormulaExpr{text: columnName(from) + ":" + columnName(to)}
+}
+
+// Reference to a block of cells: [From]1:[To]5
+func CellRange(fromColumn string, fromRow int, toColumn string, toRow int) FormulaExpr {
+	from, to := CellRef(fromColumn, fromRow), CellRef(toColumn, toRow)
+	if from.err != nil {
+		return from
+	}
+	if to.err != nil {
+		return to
+	}
+	return FormulaExpr{text: from.text + ":" + to.text}
+}
+
+// Reference to a cross-sheet reference by name: {Name}
+func SheetRef(name string) FormulaExpr {
+	return FormulaExpr{text: "{" + escapeName(name, "{}") + "}"}
+}
+
+// Literal value. Strings are quoted, times become DATE calls, nil is an empty string.
+func Literal(value interface{}) FormulaExpr {
+	switch v := value.(type) {
+	case nil:
+		return FormulaExpr{text: `""`}
+	case string:
+		return FormulaExpr{text: quoteString(v)}
+	case bool:
+		return FormulaExpr{text: strconv.FormatBool(v)}
+	case time.Time:
+		return FormulaExpr{text: fmt.Sprintf("DATE(%d, %d, %d)", v.Year(), v.Month(), v.Day())}
+	}
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return FormulaExpr{text: strconv.FormatInt(rv.Int(), 10)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return FormulaExpr{text: strconv.FormatUint(rv.Uint(), 10)}
+	case reflect.Float32, reflect.Float64:
+		return FormulaExpr{text: strconv.FormatFloat(rv.Float(), 'f', -1, 64)}
+	}
+	return FormulaExpr{err: fmt.Errorf("unsupported formula literal of type %T", value)}
+}
+
+// Function call: NAME(arg, ...)
+func Call(name string, args ...FormulaExpr) FormulaExpr {
+	texts := make([]string, len(args))
+	for i, arg := range args {
+		if arg.err != nil {
+			return arg
+		}
+		texts[i] = arg.text
+	}
+	return FormulaExpr{text: fmt.Sprintf("%s(%s)", strings.ToUpper(name), strings.Join(texts, ", "))}
+}
+
+// Operands joined by an operator such as +, -, *, /, =, <>, <, <=, >, >= or &&, in parentheses
+func Infix(operator string, operands ...FormulaExpr) FormulaExpr {
+	texts := make([]string, len(operands))
+	for i, operand := range operands {
+		if operand.err != nil {
+			return operand
+		}
+		texts[i] = operand.text
+	}
+	return FormulaExpr{text: "(" + strings.Join(texts, " "+operator+" ") + ")"}
+}
+
+// Formula text used as is. It is still checked by Formula and Sheet.Formula.
+func RawFormula(text string) FormulaExpr {
+	return FormulaExpr{text: strings.TrimPrefix(text, "=")}
+}
+
+// Return the formula text, starting with =, after checking its syntax
+func (e FormulaExpr) Formula() (string, error) {
+	if e.err != nil {
+		return "", e.err
+	}
+	formula := "=" + e.text
+	if _, _, err := scanFormula(formula); err != nil {
+		return "", err
+	}
+	return formula, nil
+}
+
+// Return the formula text, starting with =, after checking its syntax and that the columns and
+// cross-sheet references it uses exist in the sheet. Cross-sheet references are only checked when
+// the sheet was loaded with them.
+func (s Sheet) Formula(e FormulaExpr) (string, error) {
+	formula, err := e.Formula()
+	if err != nil {
+		return "", err
+	}
+	if err := s.CheckFormula(formula); err != nil {
+		return "", err
+	}
+	return formula, nil
+}
+
+// Return an error if the formula is not balanced
+func CheckFormula(formula string) error {
+	_, _, err := scanFormula(formula)
+	return err
+}
+
+// Return an error if the formula is not balanced or uses columns or cross-sheet references
+// missing from the sheet
+func (s Sheet) CheckFormula(formula string) error {
+	columns, refs, err := scanFormula(formula)
+	if err != nil {
+		return err
+	}
+	for _, title := range columns {
+		if _, err := s.GetColumnByName(title); err != nil {
+			return fmt.Errorf("formula %s: no column %s in sheet %s", formula, title, s.Name)
+		}
+	}
+	if len(s.CrossSheetReferences) == 0 {
+		return nil
+	}
+	for _, name := range refs {
+		found := false
+		for _, ref := range s.CrossSheetReferences {
+			found = found || ref.Name == name
+		}
+		if !found {
+			return fmt.Errorf("formula %s: no cross-sheet reference %s in sheet %s", formula, name, s.Name)
+		}
+	}
+	return nil
+}
+
+// Return the column titles and cross-sheet reference names used by the formula, checking that
+// quotes, brackets, braces and parentheses are balanced
+func scanFormula(formula string) (columns []string, refs []string, err error) {
+	if !strings.HasPrefix(formula, "=") {
+		return nil, nil, fmt.Errorf("formula %s does not start with =", formula)
+	}
+	depth := 0
+	for i := 1; i < len(formula); i++ {
+		switch formula[i] {
+		case '"':
+			end := strings.IndexByte(formula[i+1:], '"')
+			if end < 0 {
+				return nil, nil, fmt.Errorf("formula %s: unterminated string", formula)
+			}
+			i += end + 1
+		case '[', '{':
+			closing := byte(']')
+			if formula[i] == '{' {
+				closing = '}'
+			}
+			name, n, ok := scanName(formula[i+1:], closing)
+			if !ok {
+				return nil, nil, fmt.Errorf("formula %s: unterminated %c", formula, formula[i])
+			}
+			if closing == ']' {
+				columns = append(columns, name)
+			} else {
+				refs = append(refs, name)
+			}
+			i += n
+		case ']', '}':
+			return nil, nil, fmt.Errorf("formula %s: unexpected %c", formula, formula[i])
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, nil, fmt.Errorf("formula %s: unexpected )", formula)
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, nil, fmt.Errorf("formula %s: unbalanced parentheses", formula)
+	}
+	return columns, refs, nil
+}
+
+// Read an escaped name up to the closing character. Return the name and the number of bytes
+// consumed, including the closing character.
+func scanName(s string, closing byte) (string, int, bool) {
+	var name strings.Builder
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			if i+1 < len(s) {
+				i++
+				name.WriteByte(s[i])
+			}
+		case closing:
+			return name.String(), i + 1, true
+		default:
+			name.WriteByte(s[i])
+		}
+	}
+	return "", 0, false
+}
+
+func columnName(title string) string {
+	return "[" + escapeName(title, "[]") + "]"
+}
+
+// Escape backslashes and the given delimiters with a backslash
+func escapeName(name string, delimiters string) string {
+	var b strings.Builder
+	for _, r := range name {
+		if r == '\\' || strings.ContainsRune(delimiters, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// Quote a string literal. Formulas cannot escape double quotes, so they are spliced in with CHAR(34).
+func quoteString(s string) string {
+	if !strings.Contains(s, `"`) {
+		return `"` + s + `"`
+	}
+	parts := strings.Split(s, `"`)
+	for i := range parts {
+		parts[i] = `"` + parts[i] + `"`
+	}
+	return "(" + strings.Join(parts, " + CHAR(34) + ") + ")"
+}
@@ -0,0 +1,80 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestFormulaExpr_Formula(t *testing.T) {
+	tests := []struct {
+		name string
+		expr FormulaExpr
+		want string
+	}{
+		{"row ref", ColumnRef("Status"), "=[Status]@row"},
+		{"cell ref", CellRef("Due Date", 5), "=[Due Date]5"},
+		{"column range", ColumnRange("Status", "Status"), "=[Status]:[Status]"},
+		{"cell range", CellRange("A", 1, "B", 3), "=[A]1:[B]3"},
+		{"escaped title", ColumnRef(`Cost [USD]`), `=[Cost \[USD\]]@row`},
+		{"sheet ref", SheetRef("Other Sheet Range"), "={Other Sheet Range}"},
+		{"countifs", Call("countifs", ColumnRange("Status", "Status"), Literal("Open"), SheetRef("Other"), ColumnRef("Id")),
+			`=COUNTIFS([Status]:[Status], "Open", {Other}, [Id]@row)`},
+		{"quoted literal", Literal(`say "hi"`), `=("say " + CHAR(34) + "hi" + CHAR(34) + "")`},
+		{"numbers", Infix("+", Literal(1), Literal(2.5), Literal(false)), "=(1 + 2.5 + false)"},
+		{"date", Literal(time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)), "=DATE(2026, 10, 19)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.expr.Formula()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := Call("SUM", CellRef("A", 0)).Formula()
+	assert.Error(t, err)
+	_, err = Literal(struct{}{}).Formula()
+	assert.Error(t, err)
+}
+
+func TestCheckFormula(t *testing.T) {
+	assert.NoError(t, CheckFormula(`=IF([A]@row = "(", "]", "")`))
+	assert.Error(t, CheckFormula(`SUM([A]:[A])`))
+	assert.Error(t, CheckFormula(`=SUM([A]:[A]`))
+	assert.Error(t, CheckFormula(`=SUM([A]:[A]))`))
+	assert.Error(t, CheckFormula(`=[A@row`))
+	assert.Error(t, CheckFormula(`=A]@row`))
+	assert.Error(t, CheckFormula(`="open`))
+}
+
+func TestSheet_Formula(t *testing.T) {
+	sheet := Sheet{Name: "Tasks", Columns: []Column{{Title: "Status"}, {Title: "Cost [USD]"}}}
+	formula, err := sheet.Formula(Call("SUM", ColumnRange("Cost [USD]", "Cost [USD]")))
+	assert.NoError(t, err)
+	assert.Equal(t, `=SUM([Cost \[USD\]]:[Cost \[USD\]])`, formula)
+	_, err = sheet.Formula(ColumnRef("Owner"))
+	assert.Error(t, err)
+
+	// Cross-sheet references are checked only when the sheet was loaded with them
+	assert.NoError(t, sheet.CheckFormula("=COUNT({Other})"))
+	sheet.CrossSheetReferences = []CrossSheetReference{{Name: "Range 1"}}
+	assert.NoError(t, sheet.CheckFormula("=COUNT({Range 1})"))
+	assert.Error(t, sheet.CheckFormula("=COUNT({Other})"))
+}
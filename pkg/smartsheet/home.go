@@ -0,0 +1,75 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+type Home struct {
+	Folders    []Folder    `json:"folders"`    // Array of Folder objects
+	Reports    []Report    `json:"reports"`    // Array of Report objects
+	Sheets     []Sheet     `json:"sheets"`     // Array of Sheet objects
+	Sights     []Sight     `json:"sights"`     // Array of Sight objects
+	Templates  []Template  `json:"templates"`  // Array of Template objects
+	Workspaces []Workspace `json:"workspaces"` // Array of Workspace objects
+}
+
+// HomeOptions controls what GetHome returns
+type HomeOptions struct {
+	Include []string // Optional fields to include: ownerInfo, source
+	Exclude []string // Fields to leave out: permalinks
+}
+
+// Return Home object with everything the user can access
+func (c Client) GetHome(options *HomeOptions) (*Home, error) {
+	var home Home
+	query := url.Values{}
+	if options != nil {
+		if len(options.Include) > 0 {
+			query.Set("include", strings.Join(options.Include, ","))
+		}
+		if len(options.Exclude) > 0 {
+			query.Set("exclude", strings.Join(options.Exclude, ","))
+		}
+	}
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/home", apiEndpoint), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &home); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &home, nil
+}
+
+// Return FolderPage object with the top-level folders in Home. Create them with CreateFolder.
+func (c Client) ListHomeFolders(options *PageOptions) (*FolderPage, error) {
+	var page FolderPage
+	query := url.Values{}
+	options.setQuery(query)
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/home/folders", apiEndpoint), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &page); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &page, nil
+}
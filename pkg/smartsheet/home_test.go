@@ -0,0 +1,79 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestClient_GetHome(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/home", r.URL.Path)
+		assert.Equal(t, "source", r.URL.Query().Get("include"))
+		assert.Equal(t, "permalinks", r.URL.Query().Get("exclude"))
+		_, _ = w.Write([]byte(`{"sheets":[{"id":1,"name":"Inbox","favorite":true}],"workspaces":[{"id":2,"name":"Engineering"}]}`))
+	})
+	defer done()
+	home, err := client.GetHome(&HomeOptions{Include: []string{"source"}, Exclude: []string{"permalinks"}})
+	assert.NoError(t, err)
+	assert.True(t, home.Sheets[0].Favorite)
+	assert.Equal(t, int64(2), home.Workspaces[0].Id)
+}
+
+func TestClient_ListHomeFolders(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/home/folders", r.URL.Path)
+		_, _ = w.Write([]byte(`{"pageNumber":1,"totalPages":1,"totalCount":1,"data":[{"id":3,"name":"Personal"}]}`))
+	})
+	defer done()
+	page, err := client.ListHomeFolders(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "Personal", page.Data[0].Name)
+}
+
+func TestClient_Favorites(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "GET /favorites":
+			_, _ = w.Write([]byte(`{"pageNumber":1,"totalPages":1,"totalCount":2,"data":[{"type":"sheet","objectId":1},{"type":"workspace","objectId":2}]}`))
+		case "POST /favorites":
+			var favorites []Favorite
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&favorites))
+			assert.Equal(t, []Favorite{{Type: ItemFolder, ObjectId: 3}, {Type: ItemSight, ObjectId: 4}}, favorites)
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":[{"type":"folder","objectId":3},{"type":"sight","objectId":4}]}`))
+		case "DELETE /favorites/report":
+			assert.Equal(t, "5,6", r.URL.Query().Get("objectIds"))
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer done()
+	page, err := client.ListFavorites(&PageOptions{IncludeAll: true})
+	assert.NoError(t, err)
+	assert.Equal(t, Favorite{Type: ItemWorkspace, ObjectId: 2}, page.Data[1])
+	added, err := client.AddFavorites([]Favorite{{Type: ItemFolder, ObjectId: 3}, {Type: ItemSight, ObjectId: 4}})
+	assert.NoError(t, err)
+	assert.Len(t, *added, 2)
+	_, err = client.RemoveFavorites(ItemReport, []int64{5, 6})
+	assert.NoError(t, err)
+	_, err = client.RemoveFavorites(ItemReport, nil)
+	assert.Error(t, err)
+}
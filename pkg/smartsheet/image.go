@@ -0,0 +1,139 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"strconv"
+)
+
+// CellImageUpload describes an image to upload into a cell
+type CellImageUpload struct {
+	FileName           string // Name of the image file
+	ContentType        string // MIME type of the image, for instance image/png. Guessed from the file name extension when empty
+	AltText            string // Alternate text for the image
+	OverrideValidation bool   // Upload the image even if the column has validation that would reject it
+	Size               int64  // Length of the image in bytes. When zero the image is read into memory to measure it
+}
+
+type ImageUrl struct {
+	ImageId string       `json:"imageId"`          // Image Id
+	Height  int          `json:"height,omitempty"` // Height of the image, in pixels. The image is scaled to fit if set
+	Width   int          `json:"width,omitempty"`  // Width of the image, in pixels. The image is scaled to fit if set
+	Url     string       `json:"url,omitempty"`    // Temporary URL of the image. Only present in responses
+	Error   *ErrorObject `json:"error,omitempty"`  // Set in responses when the URL could not be created
+}
+
+type ImageUrlMap struct {
+	ImageUrls          []ImageUrl `json:"imageUrls"`          // Array of ImageUrl objects
+	UrlExpiresInMillis int64      `json:"urlExpiresInMillis"` // Milliseconds before the URLs expire
+}
+
+// Return the updated Row object with the image in the cell at rowId and columnId
+func (c Client) AddCellImage(sheetId int64, rowId int64, columnId int64, upload CellImageUpload, image io.Reader) (*Row, error) {
+	size := upload.Size
+	if size <= 0 {
+		data, err := ioutil.ReadAll(image)
+		if err != nil {
+			return nil, fmt.Errorf("could not read image: %v", err)
+		}
+		image, size = bytes.NewReader(data), int64(len(data))
+	}
+	contentType := upload.ContentType
+	if contentType == "" {
+		contentType = mime.TypeByExtension(filepath.Ext(upload.FileName))
+	}
+	if contentType == "" {
+		return nil, fmt.Errorf("could not determine content type of %s", upload.FileName)
+	}
+	query := url.Values{}
+	if upload.AltText != "" {
+		query.Set("altText", upload.AltText)
+	}
+	if upload.OverrideValidation {
+		query.Set("overrideValidation", "true")
+	}
+	headers := map[string]string{
+		"Content-Type":        contentType,
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": upload.FileName}),
+	}
+	req := c.newRequest("POST", withQuery(fmt.Sprintf("%s/sheets/%d/rows/%d/columns/%d/cellimages", apiEndpoint, sheetId, rowId, columnId), query), image, &headers)
+	req.ContentLength = size
+	resp, err := c.checkResponse(c.HTTPClient.Do(req))
+	if err != nil {
+		return nil, err
+	}
+	var row Row
+	res := ResultObject{Result: &row}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &row, nil
+}
+
+// Return ImageUrlMap object with a temporary URL for each image
+func (c Client) GetImageUrls(images []ImageUrl) (*ImageUrlMap, error) {
+	var urls ImageUrlMap
+	resp, err := c.post(fmt.Sprintf("%s/imageurls", apiEndpoint), images, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &urls); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &urls, nil
+}
+
+// Return a reader streaming the image bytes. The caller must close it.
+func (c Client) OpenImage(imageId string) (io.ReadCloser, error) {
+	urls, err := c.GetImageUrls([]ImageUrl{{ImageId: imageId}})
+	if err != nil {
+		return nil, err
+	}
+	if len(urls.ImageUrls) == 0 {
+		return nil, fmt.Errorf("no URL returned for image %s", imageId)
+	}
+	image := urls.ImageUrls[0]
+	if image.Error != nil {
+		return nil, fmt.Errorf("could not get URL for image %s: %s", imageId, image.Error.Message)
+	}
+	return c.download(image.Url, "image "+imageId)
+}
+
+// Return a reader streaming the content at a temporary URL. These URLs are pre-authorized,
+// so they are fetched without the API token. The client's Timeout is not applied, as it covers
+// reading the whole body and would cut off large files.
+func (c Client) download(url string, what string) (io.ReadCloser, error) {
+	downloader := c.HTTPClient
+	downloader.Timeout = 0
+	resp, err := downloader.Get(url)
+	if err != nil {
+		return nil, fmt.Errorf("error downloading %s: %v", what, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s. HTTP response code: %s", what, strconv.Itoa(resp.StatusCode))
+	}
+	return resp.Body, nil
+}
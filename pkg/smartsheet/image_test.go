@@ -0,0 +1,100 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestClient_AddCellImage(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/sheets/1/rows/2/columns/3/cellimages", r.URL.Path)
+		assert.Equal(t, "Logo", r.URL.Query().Get("altText"))
+		assert.Equal(t, "true", r.URL.Query().Get("overrideValidation"))
+		assert.Equal(t, "image/png", r.Header.Get("Content-Type"))
+		assert.Equal(t, `attachment; filename=logo.png`, r.Header.Get("Content-Disposition"))
+		assert.Equal(t, int64(4), r.ContentLength)
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, "\x89PNG", string(body))
+		_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":2,"cells":[{"columnId":3,"image":{"id":"img-1","altText":"Logo"}}]}}`))
+	})
+	defer done()
+	row, err := client.AddCellImage(1, 2, 3, CellImageUpload{FileName: "logo.png", AltText: "Logo", OverrideValidation: true}, strings.NewReader("\x89PNG"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), row.Id)
+	assert.Equal(t, "img-1", row.Cells[0].Image.Id)
+
+	_, err = client.AddCellImage(1, 2, 3, CellImageUpload{FileName: "logo"}, strings.NewReader("x"))
+	assert.Error(t, err)
+}
+
+func TestClient_OpenImage(t *testing.T) {
+	var serverURL string
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/imageurls":
+			var images []ImageUrl
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&images))
+			assert.Equal(t, []ImageUrl{{ImageId: "img-1"}}, images)
+			_, _ = w.Write([]byte(`{"urlExpiresInMillis":1800000,"imageUrls":[{"imageId":"img-1","url":"` + serverURL + `/download/img-1"}]}`))
+		case "/download/img-1":
+			assert.Empty(t, r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte("image bytes"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	defer done()
+	serverURL = apiEndpoint
+	image, err := client.OpenImage("img-1")
+	assert.NoError(t, err)
+	defer image.Close()
+	data, err := ioutil.ReadAll(image)
+	assert.NoError(t, err)
+	assert.Equal(t, "image bytes", string(data))
+}
+
+func TestClient_OpenImageSlowDownload(t *testing.T) {
+	var serverURL string
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/imageurls" {
+			_, _ = w.Write([]byte(`{"imageUrls":[{"imageId":"img-1","url":"` + serverURL + `/download/img-1"}]}`))
+			return
+		}
+		// Stream the body for longer than the client's timeout
+		_, _ = w.Write([]byte("image "))
+		w.(http.Flusher).Flush()
+		time.Sleep(200 * time.Millisecond)
+		_, _ = w.Write([]byte("bytes"))
+	})
+	defer done()
+	serverURL = apiEndpoint
+	client.HTTPClient.Timeout = 100 * time.Millisecond
+	image, err := client.OpenImage("img-1")
+	assert.NoError(t, err)
+	defer image.Close()
+	data, err := ioutil.ReadAll(image)
+	assert.NoError(t, err)
+	assert.Equal(t, "image bytes", string(data))
+}
@@ -0,0 +1,188 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// ObjectType identifies the variant held by an ObjectValue
+type ObjectType string
+
+const (
+	ObjectTypeAbstractDateTime ObjectType = "ABSTRACT_DATETIME"
+	ObjectTypeBoolean          ObjectType = "BOOLEAN"
+	ObjectTypeContact          ObjectType = "CONTACT"
+	ObjectTypeDate             ObjectType = "DATE"
+	ObjectTypeDateTime         ObjectType = "DATETIME"
+	ObjectTypeDuration         ObjectType = "DURATION"
+	ObjectTypeMultiContact     ObjectType = "MULTI_CONTACT"
+	ObjectTypeMultiPicklist    ObjectType = "MULTI_PICKLIST"
+	ObjectTypePredecessorList  ObjectType = "PREDECESSOR_LIST"
+)
+
+// ObjectValue is the object representation of a cell or summary field value.
+// Only the fields matching ObjectType are set. Values that are plain strings, numbers
+// or booleans in the API have an empty ObjectType and are held in Value.
+type ObjectValue struct {
+	ObjectType   ObjectType    // Type of the value
+	Value        interface{}   // The value for primitive, BOOLEAN, DATE, DATETIME and ABSTRACT_DATETIME values. Dates are ISO-8601 strings.
+	Contact      *Contact      // The contact for CONTACT values
+	Contacts     []Contact     // The contacts for MULTI_CONTACT values
+	Values       []interface{} // The selected options for MULTI_PICKLIST values, as strings or float64 numbers
+	Duration     *Duration     // The duration for DURATION values
+	Predecessors []Predecessor // The predecessors for PREDECESSOR_LIST values
+	raw          json.RawMessage
+}
+
+type Duration struct {
+	Days         float64 `json:"days,omitempty"`         // The number of days
+	Elapsed      bool    `json:"elapsed,omitempty"`      // If true, indicates the duration represents elapsed time, which ignores non-working time
+	Hours        float64 `json:"hours,omitempty"`        // The number of hours
+	Milliseconds float64 `json:"milliseconds,omitempty"` // The number of milliseconds
+	Minutes      float64 `json:"minutes,omitempty"`      // The number of minutes
+	Negative     bool    `json:"negative,omitempty"`     // When used as a predecessor's lag value, indicates whether the lag is negative (if true), or positive (false)
+	Seconds      float64 `json:"seconds,omitempty"`      // The number of seconds
+	Weeks        float64 `json:"weeks,omitempty"`        // The number of weeks
+}
+
+// Durations are always sent with their DURATION object type, including predecessor lags
+func (d Duration) MarshalJSON() ([]byte, error) {
+	type duration Duration
+	return json.Marshal(struct {
+		ObjectType ObjectType `json:"objectType"`
+		duration
+	}{ObjectTypeDuration, duration(d)})
+}
+
+type Predecessor struct {
+	RowId          int64     `json:"rowId"`                    // The Id of the predecessor row
+	RowNumber      int64     `json:"rowNumber,omitempty"`      // The row number of the predecessor row. Omitted if invalid is true.
+	Type           string    `json:"type"`                     // The type of the predecessor. One of FF, FS, SF, or SS.
+	InCriticalPath bool      `json:"inCriticalPath,omitempty"` // True if this predecessor is in the critical path
+	Invalid        bool      `json:"invalid,omitempty"`        // True if the row referenced by rowId is not a valid row in this sheet, or there is a circular reference
+	Lag            *Duration `json:"lag,omitempty"`            // The lag value of this predecessor
+}
+
+type objectValueJSON struct {
+	ObjectType   ObjectType    `json:"objectType"`
+	Value        interface{}   `json:"value,omitempty"`
+	Email        string        `json:"email,omitempty"`
+	Name         string        `json:"name,omitempty"`
+	ImageId      string        `json:"imageId,omitempty"`
+	Values       []interface{} `json:"values,omitempty"`
+	Predecessors []Predecessor `json:"predecessors,omitempty"`
+}
+
+type contactJSON struct {
+	ObjectType ObjectType `json:"objectType"`
+	Contact
+}
+
+func (o *ObjectValue) UnmarshalJSON(data []byte) error {
+	*o = ObjectValue{}
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 || data[0] != '{' {
+		return json.Unmarshal(data, &o.Value)
+	}
+	var v objectValueJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	o.ObjectType = v.ObjectType
+	switch v.ObjectType {
+	case ObjectTypeContact:
+		o.Contact = &Contact{Email: v.Email, Name: v.Name, ImageId: v.ImageId}
+	case ObjectTypeMultiContact:
+		var mc struct {
+			Values []Contact `json:"values"`
+		}
+		if err := json.Unmarshal(data, &mc); err != nil {
+			return err
+		}
+		o.Contacts = mc.Values
+	case ObjectTypeMultiPicklist:
+		o.Values = v.Values
+	case ObjectTypeDate, ObjectTypeDateTime, ObjectTypeAbstractDateTime, ObjectTypeBoolean:
+		o.Value = v.Value
+	case ObjectTypeDuration:
+		o.Duration = &Duration{}
+		if err := json.Unmarshal(data, o.Duration); err != nil {
+			return err
+		}
+	case ObjectTypePredecessorList:
+		o.Predecessors = v.Predecessors
+	default:
+		// Keep object types added to the API later so they survive being sent back
+		o.raw = append(json.RawMessage(nil), data...)
+	}
+	return nil
+}
+
+func (o ObjectValue) MarshalJSON() ([]byte, error) {
+	switch o.ObjectType {
+	case "":
+		return json.Marshal(o.Value)
+	case ObjectTypeContact:
+		var contact Contact
+		if o.Contact != nil {
+			contact = *o.Contact
+		}
+		return json.Marshal(contactJSON{ObjectType: o.ObjectType, Contact: contact})
+	case ObjectTypeMultiContact:
+		values := make([]contactJSON, len(o.Contacts))
+		for i, contact := range o.Contacts {
+			values[i] = contactJSON{ObjectType: ObjectTypeContact, Contact: contact}
+		}
+		return json.Marshal(struct {
+			ObjectType ObjectType    `json:"objectType"`
+			Values     []contactJSON `json:"values"`
+		}{o.ObjectType, values})
+	case ObjectTypeMultiPicklist:
+		values := o.Values
+		if values == nil {
+			values = []interface{}{}
+		}
+		return json.Marshal(struct {
+			ObjectType ObjectType    `json:"objectType"`
+			Values     []interface{} `json:"values"`
+		}{o.ObjectType, values})
+	case ObjectTypeDate, ObjectTypeDateTime, ObjectTypeAbstractDateTime, ObjectTypeBoolean:
+		return json.Marshal(objectValueJSON{ObjectType: o.ObjectType, Value: o.Value})
+	case ObjectTypeDuration:
+		var duration Duration
+		if o.Duration != nil {
+			duration = *o.Duration
+		}
+		return json.Marshal(duration)
+	case ObjectTypePredecessorList:
+		predecessors := o.Predecessors
+		if predecessors == nil {
+			predecessors = []Predecessor{}
+		}
+		return json.Marshal(struct {
+			ObjectType   ObjectType    `json:"objectType"`
+			Predecessors []Predecessor `json:"predecessors"`
+		}{o.ObjectType, predecessors})
+	}
+	if o.raw != nil {
+		return o.raw, nil
+	}
+	return nil, fmt.Errorf("cannot encode object value of unknown type %s", o.ObjectType)
+}
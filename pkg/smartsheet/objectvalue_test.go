@@ -0,0 +1,94 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestObjectValue_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name  string
+		json  string
+		check func(t *testing.T, o ObjectValue)
+	}{
+		{"string", `"Open"`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, "Open", o.Value)
+		}},
+		{"number", `42`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, 42.0, o.Value)
+		}},
+		{"boolean", `{"objectType":"BOOLEAN","value":true}`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, true, o.Value)
+		}},
+		{"contact", `{"objectType":"CONTACT","email":"jane@example.com","name":"Jane"}`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, "jane@example.com", o.Contact.Email)
+		}},
+		{"multi contact", `{"objectType":"MULTI_CONTACT","values":[{"objectType":"CONTACT","email":"a@example.com","name":"A"},{"objectType":"CONTACT","email":"b@example.com"}]}`, func(t *testing.T, o ObjectValue) {
+			assert.Len(t, o.Contacts, 2)
+			assert.Equal(t, "b@example.com", o.Contacts[1].Email)
+		}},
+		{"multi picklist", `{"objectType":"MULTI_PICKLIST","values":["Red","Blue"]}`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, []interface{}{"Red", "Blue"}, o.Values)
+		}},
+		{"numeric multi picklist", `{"objectType":"MULTI_PICKLIST","values":[1000000,2.5,"3"]}`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, []interface{}{1000000.0, 2.5, "3"}, o.Values)
+			options, err := Cell{ObjectValue: &o}.Strings()
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"1000000", "2.5", "3"}, options)
+		}},
+		{"date", `{"objectType":"DATE","value":"2026-10-19"}`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, "2026-10-19", o.Value)
+		}},
+		{"abstract datetime", `{"objectType":"ABSTRACT_DATETIME","value":"2026-10-19T09:00:00"}`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, ObjectTypeAbstractDateTime, o.ObjectType)
+		}},
+		{"duration", `{"objectType":"DURATION","days":2,"hours":4,"elapsed":true}`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, 2.0, o.Duration.Days)
+			assert.True(t, o.Duration.Elapsed)
+		}},
+		{"predecessor list", `{"objectType":"PREDECESSOR_LIST","predecessors":[{"rowId":10,"rowNumber":1,"type":"FS","lag":{"objectType":"DURATION","days":1,"negative":true}}]}`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, int64(10), o.Predecessors[0].RowId)
+			assert.True(t, o.Predecessors[0].Lag.Negative)
+		}},
+		{"unknown", `{"objectType":"FUTURE","something":[1,2]}`, func(t *testing.T, o ObjectValue) {
+			assert.Equal(t, ObjectType("FUTURE"), o.ObjectType)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var o ObjectValue
+			assert.NoError(t, json.Unmarshal([]byte(tt.json), &o))
+			tt.check(t, o)
+			out, err := json.Marshal(o)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.json, string(out))
+		})
+	}
+}
+
+func TestCell_ObjectValue(t *testing.T) {
+	var cell Cell
+	data := `{"columnId":1,"objectValue":{"objectType":"MULTI_PICKLIST","values":["A"]}}`
+	assert.NoError(t, json.Unmarshal([]byte(data), &cell))
+	assert.Equal(t, []interface{}{"A"}, cell.ObjectValue.Values)
+	out, err := json.Marshal(cell)
+	assert.NoError(t, err)
+	assert.JSONEq(t, data, string(out))
+}
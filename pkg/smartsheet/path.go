@@ -0,0 +1,164 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// AmbiguousPathError is returned when more than one item matches a name in a path
+type AmbiguousPathError struct {
+	Path       string     // The path up to and including the ambiguous name
+	Candidates []WalkItem // The matching items
+}
+
+func (e *AmbiguousPathError) Error() string {
+	candidates := make([]string, len(e.Candidates))
+	for i, item := range e.Candidates {
+		candidates[i] = fmt.Sprintf("%s %d", item.Type, item.Id)
+	}
+	return fmt.Sprintf("path %s is ambiguous: %s", e.Path, strings.Join(candidates, ", "))
+}
+
+// PathResolver converts between logical paths such as Workspace/Folder/Sheet and items.
+// Paths start at Home: the first name is a workspace or an item in Home. Slashes in names are
+// escaped as \/. Container contents are fetched as needed and, if Cache is set, kept between
+// calls; call Reset after changing the tree.
+type PathResolver struct {
+	Client Client
+	Cache  bool // Keep fetched contents between calls
+
+	mu       sync.Mutex
+	contents map[string][]WalkItem
+}
+
+// Return PathResolver with caching enabled
+func (c Client) NewPathResolver() *PathResolver {
+	return &PathResolver{Client: c, Cache: true}
+}
+
+// Forget cached contents
+func (r *PathResolver) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.contents = nil
+}
+
+// Return the item at the path
+func (r *PathResolver) Resolve(path string) (*WalkItem, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.begin()
+	names := splitPath(strings.Trim(path, "/"))
+	var current *WalkItem
+	for i, name := range names {
+		children, err := r.children(current)
+		if err != nil {
+			return nil, err
+		}
+		last := i == len(names)-1
+		var matches []WalkItem
+		for _, child := range children {
+			if child.Name == name && (last || child.Type == ItemFolder || child.Type == ItemWorkspace) {
+				matches = append(matches, child)
+			}
+		}
+		switch len(matches) {
+		case 0:
+			parent := "Home"
+			if current != nil {
+				parent = current.Path
+			}
+			return nil, fmt.Errorf("no item named %s in %s", name, parent)
+		case 1:
+			current = &matches[0]
+		default:
+			return nil, &AmbiguousPathError{Path: matches[0].Path, Candidates: matches}
+		}
+	}
+	return current, nil
+}
+
+// Return the path of the item with the given type and id
+func (r *PathResolver) PathOf(itemType ItemType, id int64) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.begin()
+	queue := []*WalkItem{nil}
+	for len(queue) > 0 {
+		children, err := r.children(queue[0])
+		if err != nil {
+			return "", err
+		}
+		queue = queue[1:]
+		for i := range children {
+			if children[i].Type == itemType && children[i].Id == id {
+				return children[i].Path, nil
+			}
+			if children[i].Type == ItemFolder || children[i].Type == ItemWorkspace {
+				queue = append(queue, &children[i])
+			}
+		}
+	}
+	return "", fmt.Errorf("no %s with id %d", itemType, id)
+}
+
+func (r *PathResolver) begin() {
+	if r.contents == nil || !r.Cache {
+		r.contents = map[string][]WalkItem{}
+	}
+}
+
+// Return the items in a workspace or folder, or in Home if item is nil
+func (r *PathResolver) children(item *WalkItem) ([]WalkItem, error) {
+	key := "home"
+	if item != nil {
+		key = fmt.Sprintf("%s:%d", item.Type, item.Id)
+	}
+	if children, ok := r.contents[key]; ok {
+		return children, nil
+	}
+	var children []WalkItem
+	switch {
+	case item == nil:
+		home, err := r.Client.GetHome(nil)
+		if err != nil {
+			return nil, err
+		}
+		children = containerItems("", home.Sheets, home.Reports, home.Sights, home.Templates, home.Folders)
+		for i := range home.Workspaces {
+			workspace := &home.Workspaces[i]
+			children = append(children, WalkItem{Type: ItemWorkspace, Id: workspace.Id, Name: workspace.Name, Path: joinPath("", workspace.Name), Workspace: workspace})
+		}
+	case item.Type == ItemWorkspace:
+		workspace, err := r.Client.GetWorkspace(item.Id, nil)
+		if err != nil {
+			return nil, err
+		}
+		children = containerItems(item.Path, workspace.Sheets, workspace.Reports, workspace.Sights, workspace.Templates, workspace.Folders)
+	case item.Type == ItemFolder:
+		folder, err := r.Client.GetFolder(item.Id, nil)
+		if err != nil {
+			return nil, err
+		}
+		children = containerItems(item.Path, folder.Sheets, folder.Reports, folder.Sights, folder.Templates, folder.Folders)
+	}
+	r.contents[key] = children
+	return children, nil
+}
@@ -0,0 +1,100 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+func pathTestServer(requests *int32) (*Client, func()) {
+	return testServer(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		switch r.URL.Path {
+		case "/home":
+			_, _ = w.Write([]byte(`{"sheets":[{"id":1,"name":"Inbox"}],"workspaces":[{"id":20,"name":"Engineering"},{"id":21,"name":"Sales/Marketing"}]}`))
+		case "/workspaces/20":
+			_, _ = w.Write([]byte(`{"id":20,"name":"Engineering","folders":[{"id":30,"name":"2026"}],"sheets":[{"id":2,"name":"2026"}]}`))
+		case "/workspaces/21":
+			_, _ = w.Write([]byte(`{"id":21,"name":"Sales/Marketing","sheets":[{"id":3,"name":"Leads"},{"id":4,"name":"Leads"}]}`))
+		case "/folders/30":
+			_, _ = w.Write([]byte(`{"id":30,"name":"2026","sheets":[{"id":5,"name":"Roadmap"}],"sights":[{"id":6,"name":"Roadmap"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":1006,"message":"Not Found"}`))
+		}
+	})
+}
+
+func TestPathResolver_Resolve(t *testing.T) {
+	var requests int32
+	client, done := pathTestServer(&requests)
+	defer done()
+	resolver := client.NewPathResolver()
+
+	item, err := resolver.Resolve("Inbox")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), item.Sheet.Id)
+
+	// The 2026 sheet is skipped because more names follow
+	_, err = resolver.Resolve("Engineering/2026/Roadmap")
+	assert.Error(t, err)
+	ambiguous, ok := err.(*AmbiguousPathError)
+	assert.True(t, ok)
+	assert.Equal(t, "Engineering/2026/Roadmap", ambiguous.Path)
+	assert.Len(t, ambiguous.Candidates, 2)
+	assert.Contains(t, err.Error(), "sheet 5, sight 6")
+
+	_, err = resolver.Resolve("Engineering/2026")
+	assert.Error(t, err)
+
+	_, err = resolver.Resolve("Engineering/Missing")
+	assert.EqualError(t, err, "no item named Missing in Engineering")
+
+	_, err = resolver.Resolve(`Sales\/Marketing/Leads`)
+	assert.IsType(t, &AmbiguousPathError{}, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+
+	resolver.Cache = false
+	_, err = resolver.Resolve("Inbox")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&requests))
+}
+
+func TestPathResolver_PathOf(t *testing.T) {
+	var requests int32
+	client, done := pathTestServer(&requests)
+	defer done()
+	resolver := client.NewPathResolver()
+
+	path, err := resolver.PathOf(ItemSight, 6)
+	assert.NoError(t, err)
+	assert.Equal(t, "Engineering/2026/Roadmap", path)
+
+	path, err = resolver.PathOf(ItemWorkspace, 21)
+	assert.NoError(t, err)
+	assert.Equal(t, `Sales\/Marketing`, path)
+
+	item, err := resolver.Resolve(path)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21), item.Id)
+
+	_, err = resolver.PathOf(ItemSheet, 99)
+	assert.Error(t, err)
+}
@@ -0,0 +1,476 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query filters, sorts and limits the rows of a sheet on the client side.
+// Columns are addressed by title and cell values are compared according to the column type.
+type Query struct {
+	sheet Sheet
+	where []Predicate
+	order []queryOrder
+	limit int
+}
+
+// Record is a matching row together with its cell values keyed by column title.
+// Values are typed by column: time.Time for dates, float64 for numbers, bool for checkboxes and string otherwise.
+// Blank cells are omitted, except in checkbox columns, where unchecked cells are false.
+type Record struct {
+	Row    Row
+	Values map[string]interface{}
+}
+
+// Predicate is a condition a row must satisfy to match a Query
+type Predicate interface {
+	match(r queryRow) (bool, error)
+}
+
+type queryOrder struct {
+	title      string
+	descending bool
+}
+
+type queryRow struct {
+	sheet *Sheet
+	row   *Row
+}
+
+type operator int
+
+const (
+	opEq operator = iota
+	opNe
+	opLt
+	opLe
+	opGt
+	opGe
+	opContains
+	opBlank
+)
+
+type comparison struct {
+	title string
+	op    operator
+	value interface{}
+}
+
+type conjunction struct {
+	any        bool
+	predicates []Predicate
+}
+
+type negation struct {
+	predicate Predicate
+}
+
+// Return a Query over the sheet's rows
+func (s Sheet) Query() *Query {
+	return &Query{sheet: s}
+}
+
+// Add conditions that every matching row must satisfy
+func (q *Query) Where(predicates ...Predicate) *Query {
+	q.where = append(q.where, predicates...)
+	return q
+}
+
+// Sort matching rows by the named column. Blank cells sort last. In columns mixing numbers, dates
+// and text, numbers sort first, then dates, then text. May be called more than once to break ties.
+func (q *Query) OrderBy(title string, descending bool) *Query {
+	q.order = append(q.order, queryOrder{title: title, descending: descending})
+	return q
+}
+
+// Return at most n rows. Zero means no limit.
+func (q *Query) Limit(n int) *Query {
+	q.limit = n
+	return q
+}
+
+// Return the rows matching the query
+func (q *Query) Rows() ([]Row, error) {
+	var rows []Row
+	for i := range q.sheet.Rows {
+		r := queryRow{sheet: &q.sheet, row: &q.sheet.Rows[i]}
+		ok, err := And(q.where...).match(r)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			rows = append(rows, q.sheet.Rows[i])
+		}
+	}
+	if err := q.sort(rows); err != nil {
+		return nil, err
+	}
+	if q.limit > 0 && len(rows) > q.limit {
+		rows = rows[:q.limit]
+	}
+	return rows, nil
+}
+
+// Return the rows matching the query as typed records
+func (q *Query) Records() ([]Record, error) {
+	rows, err := q.Rows()
+	if err != nil {
+		return nil, err
+	}
+	records := make([]Record, 0, len(rows))
+	for i := range rows {
+		record := Record{Row: rows[i], Values: map[string]interface{}{}}
+		for _, column := range q.sheet.Columns {
+			value, err := queryRow{sheet: &q.sheet, row: &rows[i]}.value(column.Title)
+			if err != nil {
+				return nil, err
+			}
+			if value != nil {
+				record.Values[column.Title] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+func (q *Query) sort(rows []Row) error {
+	if len(q.order) == 0 {
+		return nil
+	}
+	var sortErr error
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, o := range q.order {
+			a, err := queryRow{sheet: &q.sheet, row: &rows[i]}.value(o.title)
+			if err != nil {
+				sortErr = err
+				return false
+			}
+			b, err := queryRow{sheet: &q.sheet, row: &rows[j]}.value(o.title)
+			if err != nil {
+				sortErr = err
+				return false
+			}
+			if a == nil || b == nil {
+				if (a == nil) == (b == nil) {
+					continue
+				}
+				return b == nil
+			}
+			c := sortValues(a, b)
+			if c == 0 {
+				continue
+			}
+			return (c < 0) != o.descending
+		}
+		return false
+	})
+	return sortErr
+}
+
+// Match rows whose cell in the named column equals value
+func Eq(title string, value interface{}) Predicate {
+	return comparison{title: title, op: opEq, value: value}
+}
+
+// Match rows whose cell in the named column does not equal value
+func Ne(title string, value interface{}) Predicate {
+	return comparison{title: title, op: opNe, value: value}
+}
+
+// Match rows whose cell in the named column is less than value. Blank cells never match.
+func Lt(title string, value interface{}) Predicate {
+	return comparison{title: title, op: opLt, value: value}
+}
+
+// Match rows whose cell in the named column is less than or equal to value. Blank cells never match.
+func Le(title string, value interface{}) Predicate {
+	return comparison{title: title, op: opLe, value: value}
+}
+
+// Match rows whose cell in the named column is greater than value. Blank cells never match.
+func Gt(title string, value interface{}) Predicate {
+	return comparison{title: title, op: opGt, value: value}
+}
+
+// Match rows whose cell in the named column is greater than or equal to value. Blank cells never match.
+func Ge(title string, value interface{}) Predicate {
+	return comparison{title: title, op: opGe, value: value}
+}
+
+// Match rows whose cell in the named column contains text, ignoring case.
+// Both the cell value and its display value are searched, so contacts match by email or name.
+func Contains(title string, text string) Predicate {
+	return comparison{title: title, op: opContains, value: text}
+}
+
+// Match rows whose cell in the named column is blank
+func IsBlank(title string) Predicate {
+	return comparison{title: title, op: opBlank}
+}
+
+// Match rows satisfying every predicate
+func And(predicates ...Predicate) Predicate {
+	return conjunction{predicates: predicates}
+}
+
+// Match rows satisfying at least one predicate
+func Or(predicates ...Predicate) Predicate {
+	return conjunction{any: true, predicates: predicates}
+}
+
+// Match rows not satisfying the predicate
+func Not(predicate Predicate) Predicate {
+	return negation{predicate: predicate}
+}
+
+func (c conjunction) match(r queryRow) (bool, error) {
+	for _, p := range c.predicates {
+		ok, err := p.match(r)
+		if err != nil {
+			return false, err
+		}
+		if ok == c.any {
+			return ok, nil
+		}
+	}
+	return !c.any, nil
+}
+
+func (n negation) match(r queryRow) (bool, error) {
+	ok, err := n.predicate.match(r)
+	return !ok, err
+}
+
+func (c comparison) match(r queryRow) (bool, error) {
+	value, err := r.value(c.title)
+	if err != nil {
+		return false, err
+	}
+	switch c.op {
+	case opBlank:
+		return value == nil, nil
+	case opContains:
+		cell := r.cell(c.title)
+		if cell == nil {
+			return false, nil
+		}
+		text := strings.ToLower(fmt.Sprint(c.value))
+		if cell.Value != nil && strings.Contains(strings.ToLower(fmt.Sprint(cell.Value)), text) {
+			return true, nil
+		}
+		return strings.Contains(strings.ToLower(cell.DisplayValue), text), nil
+	}
+	if value == nil || c.value == nil {
+		blank := value == nil && c.value == nil
+		switch c.op {
+		case opEq:
+			return blank, nil
+		case opNe:
+			return !blank, nil
+		}
+		return false, nil
+	}
+	// A query value that cannot be compared with the cell, such as text against a number in a
+	// column mixing numbers and text, is never equal to it and never in range
+	cmp, ok := compareValues(value, c.value)
+	if !ok {
+		return c.op == opNe, nil
+	}
+	switch c.op {
+	case opEq:
+		return cmp == 0, nil
+	case opNe:
+		return cmp != 0, nil
+	case opLt:
+		return cmp < 0, nil
+	case opLe:
+		return cmp <= 0, nil
+	case opGt:
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+func (r queryRow) cell(title string) *Cell {
+	column, err := r.sheet.GetColumnByName(title)
+	if err != nil {
+		return nil
+	}
+	for i := range r.row.Cells {
+		if r.row.Cells[i].ColumnId == column.Id {
+			return &r.row.Cells[i]
+		}
+	}
+	return nil
+}
+
+// Return the typed value of the cell in the named column, or nil if the cell is blank
+func (r queryRow) value(title string) (interface{}, error) {
+	column, err := r.sheet.GetColumnByName(title)
+	if err != nil {
+		return nil, err
+	}
+	cell := r.cell(title)
+	if column.Type == ColumnTypeCheckbox {
+		checked := false
+		if cell != nil {
+			checked, _ = cell.Value.(bool)
+		}
+		return checked, nil
+	}
+	if cell == nil {
+		return nil, nil
+	}
+	if column.Type.IsDate() {
+		if t, err := cell.Time(); err == nil {
+			return t, nil
+		}
+	}
+	switch v := cell.Value.(type) {
+	case nil:
+		if cell.DisplayValue != "" {
+			return cell.DisplayValue, nil
+		}
+		return nil, nil
+	case float64, bool:
+		return v, nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// Compare a typed cell value with a query value converted to the same type. The values are not
+// comparable when the query value cannot be converted.
+func compareValues(a, b interface{}) (int, bool) {
+	switch av := a.(type) {
+	case time.Time:
+		bt, err := toTime(b)
+		if err != nil {
+			return 0, false
+		}
+		switch {
+		case av.Before(bt):
+			return -1, true
+		case av.After(bt):
+			return 1, true
+		}
+		return 0, true
+	case float64:
+		bf, err := toFloat(b)
+		if err != nil {
+			return 0, false
+		}
+		switch {
+		case av < bf:
+			return -1, true
+		case av > bf:
+			return 1, true
+		}
+		return 0, true
+	case bool:
+		bb, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case av == bb:
+			return 0, true
+		case bb:
+			return -1, true
+		}
+		return 1, true
+	default:
+		if _, text := b.(string); !text {
+			if _, err := toFloat(b); err == nil {
+				return 0, false
+			}
+		}
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b)), true
+	}
+}
+
+// Compare two typed cell values for sorting. Values of different types are ordered by type.
+func sortValues(a, b interface{}) int {
+	if ra, rb := sortRank(a), sortRank(b); ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+	c, _ := compareValues(a, b)
+	return c
+}
+
+func sortRank(v interface{}) int {
+	switch v.(type) {
+	case float64:
+		return 0
+	case time.Time:
+		return 1
+	case bool:
+		return 3
+	}
+	return 2
+}
+
+func toTime(v interface{}) (time.Time, error) {
+	switch t := v.(type) {
+	case time.Time:
+		return t, nil
+	case string:
+		return parseTime(t)
+	}
+	return time.Time{}, fmt.Errorf("cannot compare date with %T", v)
+}
+
+func toFloat(v interface{}) (float64, error) {
+	if s, ok := v.(string); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return 0, fmt.Errorf("cannot compare number with %q", s)
+		}
+		return f, nil
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), nil
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), nil
+	}
+	return 0, fmt.Errorf("cannot compare number with %T", v)
+}
+
+// Parse a date or datetime as returned by the API
+func parseTime(s string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02", time.RFC3339, "2006-01-02T15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("could not parse date %q", s)
+}
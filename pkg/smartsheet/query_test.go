@@ -0,0 +1,154 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func testQuerySheet() Sheet {
+	return Sheet{
+		Columns: []Column{
+			{Id: 1, Title: "Task", Type: "TEXT_NUMBER"},
+			{Id: 2, Title: "Status", Type: "PICKLIST"},
+			{Id: 3, Title: "Due", Type: "DATE"},
+			{Id: 4, Title: "Assigned", Type: "CONTACT_LIST"},
+			{Id: 5, Title: "Points", Type: "TEXT_NUMBER"},
+			{Id: 6, Title: "Done", Type: "CHECKBOX"},
+		},
+		Rows: []Row{
+			{Id: 10, Cells: []Cell{
+				{ColumnId: 1, Value: "Docs"},
+				{ColumnId: 2, Value: "Open"},
+				{ColumnId: 3, Value: "2026-10-01"},
+				{ColumnId: 4, Value: "me@example.com", DisplayValue: "Me"},
+				{ColumnId: 5, Value: 3.0},
+			}},
+			{Id: 11, Cells: []Cell{
+				{ColumnId: 1, Value: "Tests"},
+				{ColumnId: 2, Value: "Open"},
+				{ColumnId: 3, Value: "2026-09-01"},
+				{ColumnId: 4, Value: "me@example.com", DisplayValue: "Me"},
+				{ColumnId: 5, Value: 8.0},
+			}},
+			{Id: 12, Cells: []Cell{
+				{ColumnId: 1, Value: "Release"},
+				{ColumnId: 2, Value: "Open"},
+				{ColumnId: 3, Value: "2026-12-01"},
+				{ColumnId: 4, Value: "you@example.com", DisplayValue: "You"},
+			}},
+			{Id: 13, Cells: []Cell{
+				{ColumnId: 1, Value: "Plan"},
+				{ColumnId: 2, Value: "Closed"},
+				{ColumnId: 3, Value: "2026-01-01"},
+				{ColumnId: 6, Value: true},
+			}},
+		},
+	}
+}
+
+func rowIds(rows []Row) []int64 {
+	var ids []int64
+	for _, r := range rows {
+		ids = append(ids, r.Id)
+	}
+	return ids
+}
+
+func TestQuery_Rows(t *testing.T) {
+	today := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name  string
+		query *Query
+		want  []int64
+	}{
+		{"eq", testQuerySheet().Query().Where(Eq("Status", "Open")), []int64{10, 11, 12}},
+		{"and", testQuerySheet().Query().Where(Eq("Status", "Open"), Lt("Due", today), Contains("Assigned", "me")), []int64{10, 11}},
+		{"or", testQuerySheet().Query().Where(Or(Eq("Task", "Plan"), Gt("Points", 5))), []int64{11, 13}},
+		{"not", testQuerySheet().Query().Where(Not(Eq("Status", "Open"))), []int64{13}},
+		{"checkbox", testQuerySheet().Query().Where(Eq("Done", false)), []int64{10, 11, 12}},
+		{"blank", testQuerySheet().Query().Where(IsBlank("Points")), []int64{12, 13}},
+		{"date string", testQuerySheet().Query().Where(Ge("Due", "2026-10-01")), []int64{10, 12}},
+		{"contact name", testQuerySheet().Query().Where(Contains("Assigned", "YOU")), []int64{12}},
+		{"order", testQuerySheet().Query().OrderBy("Due", false), []int64{13, 11, 10, 12}},
+		{"order blanks last", testQuerySheet().Query().OrderBy("Points", true), []int64{11, 10, 12, 13}},
+		{"limit", testQuerySheet().Query().Where(Eq("Status", "Open")).OrderBy("Due", true).Limit(2), []int64{12, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := tt.query.Rows()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rowIds(rows))
+		})
+	}
+}
+
+func TestQuery_RowsErrors(t *testing.T) {
+	_, err := testQuerySheet().Query().Where(Eq("Missing", 1)).Rows()
+	assert.Error(t, err)
+	_, err = testQuerySheet().Query().OrderBy("Missing", false).Rows()
+	assert.Error(t, err)
+}
+
+func TestQuery_MixedValues(t *testing.T) {
+	sheet := Sheet{
+		Columns: []Column{{Id: 1, Title: "Ref", Type: "TEXT_NUMBER"}},
+		Rows: []Row{
+			{Id: 10, Cells: []Cell{{ColumnId: 1, Value: "n/a"}}},
+			{Id: 11, Cells: []Cell{{ColumnId: 1, Value: 42.0}}},
+			{Id: 12},
+			{Id: 13, Cells: []Cell{{ColumnId: 1, Value: 7.0}}},
+			{Id: 14, Cells: []Cell{{ColumnId: 1, Value: "abc"}}},
+		},
+	}
+	tests := []struct {
+		name  string
+		query *Query
+		want  []int64
+	}{
+		// Text never equals or is in range of a number, and the other way round
+		{"eq text", sheet.Query().Where(Eq("Ref", "n/a")), []int64{10}},
+		{"eq number", sheet.Query().Where(Eq("Ref", 42)), []int64{11}},
+		{"ne text", sheet.Query().Where(Ne("Ref", "n/a")), []int64{11, 12, 13, 14}},
+		{"gt number", sheet.Query().Where(Gt("Ref", 5)), []int64{11, 13}},
+		{"lt text", sheet.Query().Where(Lt("Ref", "b")), []int64{14}},
+		{"order", sheet.Query().OrderBy("Ref", false), []int64{13, 11, 14, 10, 12}},
+		{"order descending", sheet.Query().OrderBy("Ref", true), []int64{10, 14, 11, 13, 12}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := tt.query.Rows()
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, rowIds(rows))
+		})
+	}
+	rows, err := testQuerySheet().Query().Where(Lt("Due", 5)).Rows()
+	assert.NoError(t, err)
+	assert.Empty(t, rows)
+}
+
+func TestQuery_Records(t *testing.T) {
+	records, err := testQuerySheet().Query().Where(Eq("Task", "Docs")).Records()
+	assert.NoError(t, err)
+	assert.Len(t, records, 1)
+	assert.Equal(t, time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC), records[0].Values["Due"])
+	assert.Equal(t, 3.0, records[0].Values["Points"])
+	assert.Equal(t, false, records[0].Values["Done"])
+	assert.Equal(t, "me@example.com", records[0].Values["Assigned"])
+}
@@ -16,7 +16,145 @@
 
 package smartsheet
 
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+type Report struct {
+	Id            int64          `json:"id"`                      // Report Id
+	Name          string         `json:"name"`                    // Report name
+	AccessLevel   AccessLevel    `json:"accessLevel,omitempty"`   // User's permissions on the report
+	Columns       []ReportColumn `json:"columns,omitempty"`       // Array of ReportColumn objects
+	Favorite      bool           `json:"favorite,omitempty"`      // Returned only if the user has marked the report as a favorite in their Home tab (value = true)
+	ModifiedAt    string         `json:"modifiedAt,omitempty"`    // Time that the report was modified
+	Permalink     string         `json:"permalink,omitempty"`     // URL that represents a direct link to the report in Smartsheet
+	ReadOnly      bool           `json:"readOnly,omitempty"`      // Returned only if the report belongs to an expired trial (value = true)
+	Rows          []ReportRow    `json:"rows,omitempty"`          // Array of ReportRow objects
+	Scope         *Scope         `json:"scope,omitempty"`         // A report's scope can be defined as the sheet ids and workspace ids that make up the report. Only included if the include parameter specifies scope.
+	SourceSheets  []Sheet        `json:"sourceSheets,omitempty"`  // Array of Sheet objects (without rows), representing the sheets that rows in the report originated from. Only included in the Get Report response if the include parameter specifies sourceSheets.
+	TotalRowCount int            `json:"totalRowCount,omitempty"` // The total number of rows in the report
+	Version       int            `json:"version,omitempty"`       // Report version, incremented every time the report is modified
+}
+
+// ReportColumn is a report column. It combines the columns of the source sheets that share a title and type.
+type ReportColumn struct {
+	Column
+	VirtualId       int64 `json:"virtualId"`                 // The virtual Id of this report column
+	SheetNameColumn bool  `json:"sheetNameColumn,omitempty"` // Returned only for the special "Sheet Name" report column (value = true)
+}
+
+// ReportRow is a report row. Its Id is the Id of the row in its source sheet.
+type ReportRow struct {
+	Row
+	Cells []ReportCell `json:"cells"` // Array of ReportCell objects belonging to the row
+}
+
+// ReportCell is a report cell. Its ColumnId is the Id of the column in the source sheet.
+type ReportCell struct {
+	Cell
+	VirtualColumnId int64 `json:"virtualColumnId"` // The virtual Id of the cell's report column
+}
+
+// ReportPage is a page of reports returned by ListReports
+type ReportPage struct {
+	PageInfo
+	Data []Report `json:"data"`
+}
+
+// GetReportOptions controls what GetReport returns
+type GetReportOptions struct {
+	PageOptions
+	Include []string // Optional elements to include: attachments, discussions, format, objectValue, scope, source, sourceSheets
+	Level   int      // Compatibility level of multi-contact and multi-picklist values. Defaults to the oldest level
+}
+
 type Scope struct {
 	Sheets     []Sheet     `json:"sheets"`     // Array of Sheet objects (containing just the sheet ID) of any sheets that the requestor has access to that make up the report
 	Workspaces []Workspace `json:"workspaces"` // Array of Workspace objects (containing just the workspace ID) that the requestor has access to that make up the report
 }
+
+// Return ReportPage object
+func (c Client) ListReports(options *PageOptions) (*ReportPage, error) {
+	var page ReportPage
+	query := url.Values{}
+	options.setQuery(query)
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/reports", apiEndpoint), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &page); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &page, nil
+}
+
+// Return Report object with its columns and a page of rows
+func (c Client) GetReport(reportId int64, options *GetReportOptions) (*Report, error) {
+	var report Report
+	query := url.Values{}
+	if options != nil {
+		options.PageOptions.setQuery(query)
+		if len(options.Include) > 0 {
+			query.Set("include", strings.Join(options.Include, ","))
+		}
+		if options.Level > 0 {
+			query.Set("level", strconv.Itoa(options.Level))
+		}
+	}
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/reports/%d", apiEndpoint, reportId), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &report); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &report, nil
+}
+
+// Return ReportColumn object with title name
+func (r Report) GetColumnByName(name string) (*ReportColumn, error) {
+	for i := range r.Columns {
+		if r.Columns[i].Title == name {
+			return &r.Columns[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no column with value %s", name)
+}
+
+// Return ReportColumn object with virtual id
+func (r Report) GetColumnByVirtualId(virtualId int64) (*ReportColumn, error) {
+	for i := range r.Columns {
+		if r.Columns[i].VirtualId == virtualId {
+			return &r.Columns[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no column with value %d", virtualId)
+}
+
+// Return the row's ReportCell object in the report column with virtual id
+func (r ReportRow) GetCell(virtualColumnId int64) (*ReportCell, error) {
+	for i := range r.Cells {
+		if r.Cells[i].VirtualColumnId == virtualColumnId {
+			return &r.Cells[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no cell in column %d of row %d", virtualColumnId, r.Id)
+}
+
+// Marshal the cell with its virtual column Id, which the embedded Cell's MarshalJSON would drop
+func (c ReportCell) MarshalJSON() ([]byte, error) {
+	cell, err := json.Marshal(c.Cell)
+	if err != nil || c.VirtualColumnId == 0 {
+		return cell, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(cell, &fields); err != nil {
+		return nil, err
+	}
+	fields["virtualColumnId"] = json.RawMessage(strconv.FormatInt(c.VirtualColumnId, 10))
+	return json.Marshal(fields)
+}
@@ -0,0 +1,74 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestClient_ListReports(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/reports", r.URL.Path)
+		_, _ = w.Write([]byte(`{"pageNumber":1,"totalPages":1,"totalCount":1,"data":[{"id":20,"name":"Open items","accessLevel":"VIEWER"}]}`))
+	})
+	defer done()
+	page, err := client.ListReports(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(20), page.Data[0].Id)
+	assert.Equal(t, AccessLevelViewer, page.Data[0].AccessLevel)
+}
+
+func TestClient_GetReport(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/reports/20", r.URL.Path)
+		query := r.URL.Query()
+		assert.Equal(t, "2", query.Get("page"))
+		assert.Equal(t, "50", query.Get("pageSize"))
+		assert.Equal(t, "scope,sourceSheets", query.Get("include"))
+		assert.Equal(t, "2", query.Get("level"))
+		_, _ = w.Write([]byte(`{"id":20,"name":"Open items","totalRowCount":51,
+			"columns":[{"virtualId":1,"title":"Sheet Name","type":"TEXT_NUMBER","sheetNameColumn":true},{"virtualId":2,"title":"Status","type":"PICKLIST"}],
+			"rows":[{"id":1000,"sheetId":10,"rowNumber":1,"cells":[{"virtualColumnId":1,"value":"Plan"},{"columnId":101,"virtualColumnId":2,"value":"Open"}]}],
+			"scope":{"sheets":[{"id":10}]},"sourceSheets":[{"id":10,"name":"Plan"}]}`))
+	})
+	defer done()
+	report, err := client.GetReport(20, &GetReportOptions{PageOptions: PageOptions{Page: 2, PageSize: 50}, Include: []string{"scope", "sourceSheets"}, Level: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, 51, report.TotalRowCount)
+	assert.True(t, report.Columns[0].SheetNameColumn)
+	assert.Equal(t, int64(10), report.Scope.Sheets[0].Id)
+	assert.Equal(t, "Plan", report.SourceSheets[0].Name)
+
+	status, err := report.GetColumnByName("Status")
+	assert.NoError(t, err)
+	assert.Equal(t, ColumnTypePicklist, status.Type)
+	row := report.Rows[0]
+	assert.Equal(t, int64(10), row.SheetId)
+	cell, err := row.GetCell(status.VirtualId)
+	assert.NoError(t, err)
+	assert.Equal(t, "Open", cell.Value)
+	assert.Equal(t, int64(101), cell.ColumnId)
+	_, err = report.GetColumnByVirtualId(9)
+	assert.Error(t, err)
+
+	out, err := json.Marshal(*cell)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"columnId":101,"virtualColumnId":2,"value":"Open"}`, string(out))
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ReportEdit is a change to a report row, written back to the row in its source sheet
+type ReportEdit struct {
+	SheetId int64        // Source sheet of the row
+	RowId   int64        // Id of the row in its source sheet
+	Cells   []ReportCell // New cell contents, identified by VirtualColumnId
+}
+
+// ReportEditResult is the outcome of a ReportEdit
+type ReportEditResult struct {
+	SheetId int64 // Source sheet of the row
+	RowId   int64 // Id of the row in its source sheet
+	Row     *Row  // The updated row, if the update succeeded
+	Err     error // Why the update failed
+}
+
+// Return an empty edit of the report row
+func (r ReportRow) Edit() *ReportEdit {
+	return &ReportEdit{SheetId: r.SheetId, RowId: r.Id}
+}
+
+// Set the value of the cell in the report column with virtual id
+func (e *ReportEdit) Set(virtualColumnId int64, value interface{}) *ReportEdit {
+	e.Cells = append(e.Cells, ReportCell{Cell: Cell{Value: value}, VirtualColumnId: virtualColumnId})
+	return e
+}
+
+// Write edits made against the report's virtual columns back to the source sheets. Virtual columns
+// are resolved to the columns of each source sheet from the report's cells, or from the report's
+// source sheets by title when the report was fetched with include sourceSheets. Edits are grouped
+// into one bulk row update per sheet, and the edits of a row are merged into a single row. Results
+// are in report order, followed by edits of rows that are not in the report. An error is returned
+// if any edit failed, alongside the results.
+func (c Client) UpdateReportRows(report Report, edits []ReportEdit) ([]ReportEditResult, error) {
+	position := map[[2]int64]int{}
+	for i, row := range report.Rows {
+		position[[2]int64{row.SheetId, row.Id}] = i
+	}
+	order := make([]int, len(edits))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		pa, okA := position[[2]int64{edits[order[a]].SheetId, edits[order[a]].RowId}]
+		pb, okB := position[[2]int64{edits[order[b]].SheetId, edits[order[b]].RowId}]
+		return okA && (!okB || pa < pb)
+	})
+
+	results := make([]ReportEditResult, len(edits))
+	updates := make([]Row, len(edits))
+	bySheet := map[int64][]int{}
+	var sheetIds []int64
+	for i, index := range order {
+		edit := edits[index]
+		results[i] = ReportEditResult{SheetId: edit.SheetId, RowId: edit.RowId}
+		var err error
+		if updates[i], err = report.resolveEdit(edit); err != nil {
+			results[i].Err = err
+			continue
+		}
+		if _, ok := bySheet[edit.SheetId]; !ok {
+			sheetIds = append(sheetIds, edit.SheetId)
+		}
+		bySheet[edit.SheetId] = append(bySheet[edit.SheetId], i)
+	}
+
+	for _, sheetId := range sheetIds {
+		// Edits of the same row are merged, as a row can only appear once in an update
+		var rows []Row
+		merged := map[int64]int{}
+		rowOf := map[int]int{}
+		for _, i := range bySheet[sheetId] {
+			j, ok := merged[updates[i].Id]
+			if !ok {
+				j = len(rows)
+				merged[updates[i].Id] = j
+				rows = append(rows, Row{Id: updates[i].Id})
+			}
+			rows[j].Cells = mergeCells(rows[j].Cells, updates[i].Cells)
+			rowOf[i] = j
+		}
+		bulk, err := c.NewBulkWriter(sheetId).UpdateRows(rows)
+		failed := map[int]error{}
+		if bulk != nil {
+			for _, f := range bulk.Failures {
+				failed[f.Index] = errors.New(f.Error.Message)
+			}
+		}
+		for _, i := range bySheet[sheetId] {
+			j := rowOf[i]
+			switch {
+			case failed[j] != nil:
+				results[i].Err = failed[j]
+			case bulk == nil || bulk.RowIds[j] == 0:
+				if err == nil {
+					err = fmt.Errorf("row %d of sheet %d was not returned by the update", rows[j].Id, sheetId)
+				}
+				results[i].Err = err
+			default:
+				updated := bulk.Rows[j]
+				results[i].Row = &updated
+			}
+		}
+	}
+
+	failures := 0
+	for _, result := range results {
+		if result.Err != nil {
+			failures++
+		}
+	}
+	if failures > 0 {
+		return results, fmt.Errorf("%d of %d report rows failed", failures, len(edits))
+	}
+	return results, nil
+}
+
+// Return cells with updates applied, replacing earlier cells of the same column
+func mergeCells(cells []Cell, updates []Cell) []Cell {
+	for _, update := range updates {
+		replaced := false
+		for k := range cells {
+			if cells[k].ColumnId == update.ColumnId {
+				cells[k] = update
+				replaced = true
+			}
+		}
+		if !replaced {
+			cells = append(cells, update)
+		}
+	}
+	return cells
+}
+
+// Return the source sheet row update for an edit
+func (r Report) resolveEdit(edit ReportEdit) (Row, error) {
+	row := Row{Id: edit.RowId}
+	for _, cell := range edit.Cells {
+		column, err := r.GetColumnByVirtualId(cell.VirtualColumnId)
+		if err != nil {
+			return row, err
+		}
+		if column.SheetNameColumn {
+			return row, fmt.Errorf("the %s column cannot be edited", column.Title)
+		}
+		columnId, err := r.sourceColumnId(edit.SheetId, column)
+		if err != nil {
+			return row, err
+		}
+		update := cell.Cell
+		update.ColumnId = columnId
+		row.Cells = append(row.Cells, update)
+	}
+	return row, nil
+}
+
+// Return the id of the column of the source sheet behind a report column
+func (r Report) sourceColumnId(sheetId int64, column *ReportColumn) (int64, error) {
+	for _, row := range r.Rows {
+		if row.SheetId != sheetId {
+			continue
+		}
+		for _, cell := range row.Cells {
+			if cell.VirtualColumnId == column.VirtualId && cell.ColumnId != 0 {
+				return cell.ColumnId, nil
+			}
+		}
+	}
+	for _, sheet := range r.SourceSheets {
+		if sheet.Id != sheetId {
+			continue
+		}
+		if sourceColumn, err := sheet.GetColumnByName(column.Title); err == nil && (column.Type == "" || sourceColumn.Type == column.Type) {
+			return sourceColumn.Id, nil
+		}
+	}
+	return 0, fmt.Errorf("sheet %d has no column for report column %s", sheetId, column.Title)
+}
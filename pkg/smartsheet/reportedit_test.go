@@ -0,0 +1,126 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestClient_UpdateReportRows(t *testing.T) {
+	report := Report{
+		Columns: []ReportColumn{
+			{VirtualId: 1, Column: Column{Title: "Sheet Name"}, SheetNameColumn: true},
+			{VirtualId: 2, Column: Column{Title: "Status", Type: ColumnTypePicklist}},
+		},
+		Rows: []ReportRow{
+			{Row: Row{Id: 1000, SheetId: 10}, Cells: []ReportCell{{Cell: Cell{ColumnId: 101}, VirtualColumnId: 2}}},
+			{Row: Row{Id: 2000, SheetId: 20}, Cells: []ReportCell{{VirtualColumnId: 2}}},
+			{Row: Row{Id: 1001, SheetId: 10}, Cells: []ReportCell{{Cell: Cell{ColumnId: 101}, VirtualColumnId: 2}}},
+		},
+		SourceSheets: []Sheet{{Id: 20, Columns: []Column{{Id: 201, Title: "Status", Type: ColumnTypePicklist}}}},
+	}
+	requests := map[string][]Row{}
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		var rows []Row
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&rows))
+		requests[r.URL.Path] = rows
+		if r.URL.Path == "/sheets/20/rows" {
+			_, _ = w.Write([]byte(`{"message":"PARTIAL_SUCCESS","resultCode":3,"result":[],"failedItems":[{"index":0,"rowId":2000,"error":{"errorCode":1036,"message":"row is locked"}}]}`))
+			return
+		}
+		updated := make([]Row, len(rows))
+		for i, row := range rows {
+			row.SheetId = 10
+			updated[i] = row
+		}
+		_ = json.NewEncoder(w).Encode(ResultObject{Message: "SUCCESS", Result: updated})
+	})
+	defer done()
+
+	edits := []ReportEdit{
+		*report.Rows[2].Edit().Set(2, "Done"),
+		*report.Rows[1].Edit().Set(2, "Open"),
+		*report.Rows[0].Edit().Set(2, "Open"),
+		{SheetId: 10, RowId: 1000, Cells: []ReportCell{{Cell: Cell{Value: "x"}, VirtualColumnId: 1}}},
+		*(&ReportRow{Row: Row{Id: 3000, SheetId: 30}}).Edit().Set(2, "Open"),
+	}
+	results, err := client.UpdateReportRows(report, edits)
+	assert.EqualError(t, err, "3 of 5 report rows failed")
+
+	// Rows of sheet 10 are sent in one request, in report order
+	assert.Equal(t, []Row{
+		{Id: 1000, Cells: []Cell{{ColumnId: 101, Value: "Open"}}},
+		{Id: 1001, Cells: []Cell{{ColumnId: 101, Value: "Done"}}},
+	}, requests["/sheets/10/rows"])
+	assert.Equal(t, []Row{{Id: 2000, Cells: []Cell{{ColumnId: 201, Value: "Open"}}}}, requests["/sheets/20/rows"])
+
+	assert.Len(t, results, 5)
+	assert.Equal(t, int64(1000), results[0].RowId)
+	assert.Equal(t, int64(10), results[0].Row.SheetId)
+	assert.Contains(t, results[1].Err.Error(), "cannot be edited")
+	assert.Equal(t, int64(2000), results[2].RowId)
+	assert.EqualError(t, results[2].Err, "row is locked")
+	assert.Equal(t, int64(1001), results[3].RowId)
+	assert.NoError(t, results[3].Err)
+	assert.Equal(t, int64(3000), results[4].RowId)
+	assert.EqualError(t, results[4].Err, "sheet 30 has no column for report column Status")
+}
+
+func TestClient_UpdateReportRowsMergesRows(t *testing.T) {
+	report := Report{
+		Columns: []ReportColumn{
+			{VirtualId: 2, Column: Column{Title: "Status"}},
+			{VirtualId: 3, Column: Column{Title: "Owner"}},
+		},
+		Rows: []ReportRow{
+			{Row: Row{Id: 1000, SheetId: 10}, Cells: []ReportCell{{Cell: Cell{ColumnId: 101}, VirtualColumnId: 2}, {Cell: Cell{ColumnId: 102}, VirtualColumnId: 3}}},
+			{Row: Row{Id: 2000, SheetId: 20}, Cells: []ReportCell{{Cell: Cell{ColumnId: 201}, VirtualColumnId: 2}}},
+		},
+	}
+	requests := map[string][]Row{}
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		var rows []Row
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&rows))
+		requests[r.URL.Path] = rows
+		if r.URL.Path == "/sheets/20/rows" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errorCode":1006,"message":"Not Found"}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(ResultObject{Message: "SUCCESS", Result: rows})
+	})
+	defer done()
+
+	edits := []ReportEdit{
+		*report.Rows[0].Edit().Set(2, "Open"),
+		*report.Rows[0].Edit().Set(3, "jane@example.com").Set(2, "Done"),
+		*report.Rows[1].Edit().Set(2, "Open"),
+	}
+	results, err := client.UpdateReportRows(report, edits)
+	assert.EqualError(t, err, "1 of 3 report rows failed")
+
+	// Both edits of row 1000 are sent as one row, the later value winning
+	assert.Equal(t, []Row{{Id: 1000, Cells: []Cell{{ColumnId: 101, Value: "Done"}, {ColumnId: 102, Value: "jane@example.com"}}}}, requests["/sheets/10/rows"])
+	assert.Equal(t, int64(1000), results[0].Row.Id)
+	assert.Equal(t, int64(1000), results[1].Row.Id)
+	// A failed request fails every row it carried
+	assert.Nil(t, results[2].Row)
+	assert.Error(t, results[2].Err)
+}
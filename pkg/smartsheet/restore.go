@@ -0,0 +1,445 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// RestoreReport maps the ids of a backup to the ids of the restored objects
+type RestoreReport struct {
+	WorkspaceId int64           `json:"workspaceId"` // Id of the workspace restored into
+	Folders     map[int64]int64 `json:"folders"`     // Old folder id to new folder id
+	Sheets      map[int64]int64 `json:"sheets"`      // Old sheet id to new sheet id
+	Columns     map[int64]int64 `json:"columns"`     // Old column id to new column id
+	Rows        map[int64]int64 `json:"rows"`        // Old row id to new row id
+	Skipped     []RestoreSkip   `json:"skipped"`     // Cross-sheet references and cell links to sheets outside of the backup
+	Warnings    []string        `json:"warnings"`    // Items that were not restored and references that were kept as is
+	backedUp    map[int64]bool  // Old ids of the sheets in the backup
+}
+
+// RestoreSkip is a cross-sheet reference or cell link that was not restored because it points to
+// a sheet outside of the backup, which may not exist in the target account
+type RestoreSkip struct {
+	Type          string `json:"type"`               // crossSheetReference or cellLink
+	SheetId       int64  `json:"sheetId"`            // Old id of the sheet holding the reference or link
+	Name          string `json:"name,omitempty"`     // Name of the cross-sheet reference
+	RowId         int64  `json:"rowId,omitempty"`    // Old id of the row of the linked cell
+	ColumnId      int64  `json:"columnId,omitempty"` // Old id of the column of the linked cell
+	SourceSheetId int64  `json:"sourceSheetId"`      // Id of the sheet the reference or link points to
+}
+
+// Restore rebuilds the folders and sheets of a backup made with Backup in a workspace, which can
+// belong to another account. Sheets are restored with their columns, rows, hierarchy, formats and
+// discussions. Sheet, row and column ids in cross-sheet references, cell links, hyperlinks and
+// predecessors are remapped to the restored objects. Cross-sheet references and cell links to
+// sheets outside of the backup are skipped and listed in the report, and linked cells keep their
+// last value. Hyperlinks to objects outside of the backup are kept as is and reported. Formulas
+// refer to columns by title, rows by number and other sheets by cross-sheet reference name, so
+// they are restored as is once the references have been recreated. Reports, Sights, templates
+// and attachments are not restored.
+type Restore struct {
+	Client        Client
+	WorkspaceId   int64  // Workspace to restore into. A new workspace is created when zero
+	WorkspaceName string // Name of the new workspace. Defaults to the name of the backed up workspace
+}
+
+type restoredSheet struct {
+	old   *Sheet
+	newId int64
+}
+
+// Return Restore with default settings
+func (c Client) NewRestore() *Restore {
+	return &Restore{Client: c}
+}
+
+// Restore the backup directory or tar.gz archive at path
+func (r *Restore) FromBackup(path string) (*RestoreReport, error) {
+	manifest, err := ReadBackupManifest(path)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, item := range manifest.Items {
+		if item.Type == ItemSheet {
+			names = append(names, item.File)
+		}
+	}
+	files, err := readBackupFiles(path, names)
+	if err != nil {
+		return nil, err
+	}
+	report := &RestoreReport{
+		WorkspaceId: r.WorkspaceId,
+		Folders:     map[int64]int64{},
+		Sheets:      map[int64]int64{},
+		Columns:     map[int64]int64{},
+		Rows:        map[int64]int64{},
+		backedUp:    map[int64]bool{},
+	}
+	for _, item := range manifest.Items {
+		if _, ok := files[item.File]; ok && item.Type == ItemSheet {
+			report.backedUp[item.Id] = true
+		}
+	}
+	if report.WorkspaceId == 0 {
+		name := r.WorkspaceName
+		if name == "" {
+			name = manifest.WorkspaceName
+		}
+		workspace, err := r.Client.CreateWorkspace(name)
+		if err != nil {
+			return nil, err
+		}
+		report.WorkspaceId = workspace.Id
+	}
+
+	// Folders are listed in the manifest before their contents
+	var sheets []restoredSheet
+	for _, item := range manifest.Items {
+		parent := report.Folders[item.ParentId]
+		switch item.Type {
+		case ItemFolder:
+			var folder *Folder
+			if parent == 0 {
+				folder, err = r.Client.CreateFolderInWorkspace(report.WorkspaceId, item.Name)
+			} else {
+				folder, err = r.Client.CreateFolderInFolder(parent, item.Name)
+			}
+			if err != nil {
+				return report, fmt.Errorf("could not restore folder %s: %v", item.Path, err)
+			}
+			report.Folders[item.Id] = folder.Id
+		case ItemSheet:
+			data, ok := files[item.File]
+			if !ok {
+				report.warn("sheet %s was not restored: %s is not in the backup", item.Path, item.File)
+				continue
+			}
+			var sheet Sheet
+			if err := json.Unmarshal(data, &sheet); err != nil {
+				return report, fmt.Errorf("could not decode %s: %v", item.File, err)
+			}
+			newId, err := r.createSheet(report, parent, &sheet)
+			if err != nil {
+				return report, fmt.Errorf("could not restore sheet %s: %v", item.Path, err)
+			}
+			sheets = append(sheets, restoredSheet{old: &sheet, newId: newId})
+		default:
+			report.warn("%s %s was not restored", item.Type, item.Path)
+		}
+	}
+	// References are restored once every sheet exists
+	for _, sheet := range sheets {
+		if err := r.relink(report, sheet.old, sheet.newId); err != nil {
+			return report, fmt.Errorf("could not restore references of sheet %s: %v", sheet.old.Name, err)
+		}
+	}
+	return report, nil
+}
+
+// Create the sheet with its columns and rows, without references to other objects
+func (r *Restore) createSheet(report *RestoreReport, folderId int64, sheet *Sheet) (int64, error) {
+	columns := make([]columnRequest, len(sheet.Columns))
+	for i, column := range sheet.Columns {
+		columns[i] = column.request()
+		// Column formulas are set once every column and sheet they refer to exists
+		columns[i].Formula = ""
+	}
+	path := fmt.Sprintf("%s/workspaces/%d/sheets", apiEndpoint, report.WorkspaceId)
+	if folderId != 0 {
+		path = fmt.Sprintf("%s/folders/%d/sheets", apiEndpoint, folderId)
+	}
+	var created Sheet
+	res := ResultObject{Result: &created}
+	resp, err := r.Client.post(path, map[string]interface{}{"name": sheet.Name, "columns": columns}, nil)
+	if err != nil {
+		return 0, err
+	}
+	if dErr := r.Client.decodeJSON(resp, &res); dErr != nil {
+		return 0, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	if len(created.Columns) != len(sheet.Columns) {
+		return 0, fmt.Errorf("created sheet has %d columns instead of %d", len(created.Columns), len(sheet.Columns))
+	}
+	report.Sheets[sheet.Id] = created.Id
+	for i := range sheet.Columns {
+		report.Columns[sheet.Columns[i].Id] = created.Columns[i].Id
+	}
+
+	// Rows are added one level of the hierarchy at a time, in sheet order, so that each row
+	// can be placed as the last child of its already restored parent. Rows of one request must
+	// share their location, so the children of each parent are added separately.
+	depth := map[int64]int{}
+	var levels [][]Row
+	for _, row := range sheet.Rows {
+		d := 0
+		if row.ParentId != 0 {
+			d = depth[row.ParentId] + 1
+		}
+		depth[row.Id] = d
+		for len(levels) <= d {
+			levels = append(levels, nil)
+		}
+		levels[d] = append(levels[d], row)
+	}
+	writer := r.Client.NewBulkWriter(created.Id)
+	writer.Workers = 1
+	for _, level := range levels {
+		var parents []int64
+		children := map[int64][]Row{}
+		for _, row := range level {
+			if _, ok := children[row.ParentId]; !ok {
+				parents = append(parents, row.ParentId)
+			}
+			children[row.ParentId] = append(children[row.ParentId], row)
+		}
+		for _, parent := range parents {
+			group := children[parent]
+			rows := make([]Row, len(group))
+			for i, row := range group {
+				rows[i] = Row{
+					ParentId: report.Rows[parent],
+					ToBottom: true,
+					Format:   row.Format,
+					Locked:   row.Locked,
+					Cells:    r.valueCells(report, sheet, row),
+				}
+			}
+			result, err := writer.AddRows(rows)
+			if err != nil {
+				return 0, err
+			}
+			for i, row := range group {
+				report.Rows[row.Id] = result.RowIds[i]
+			}
+		}
+	}
+	return created.Id, nil
+}
+
+// Return the cells of the row that do not refer to other objects
+func (r *Restore) valueCells(report *RestoreReport, sheet *Sheet, row Row) []Cell {
+	cells := []Cell{}
+	for _, cell := range row.Cells {
+		column, err := sheet.GetColumnById(cell.ColumnId)
+		if err != nil || column.SystemColumnType != "" || column.Formula != "" {
+			continue
+		}
+		// Cells linked from sheets outside of the backup keep their value instead of the link
+		if cell.Formula != "" || (cell.LinkInFromCell != nil && report.backedUp[cell.LinkInFromCell.SheetId]) || isObjectHyperlink(cell.Hyperlink) {
+			continue
+		}
+		restored := Cell{ColumnId: report.Columns[cell.ColumnId], Format: cell.Format, Hyperlink: cell.Hyperlink}
+		switch {
+		case cell.ObjectValue != nil && cell.ObjectValue.ObjectType == ObjectTypePredecessorList:
+			continue
+		case cell.ObjectValue != nil && (cell.ObjectValue.ObjectType == ObjectTypeMultiContact || cell.ObjectValue.ObjectType == ObjectTypeMultiPicklist):
+			restored.ObjectValue = cell.ObjectValue
+		default:
+			restored.Value = cell.Value
+		}
+		if restored.Value == nil && restored.ObjectValue == nil && restored.Hyperlink == nil && restored.Format == "" {
+			continue
+		}
+		cells = append(cells, restored)
+	}
+	return cells
+}
+
+// Restore the cross-sheet references, column formulas, cell formulas, links, hyperlinks,
+// predecessors and discussions of a sheet
+func (r *Restore) relink(report *RestoreReport, sheet *Sheet, sheetId int64) error {
+	skippedRefs := map[string]bool{}
+	for _, ref := range sheet.CrossSheetReferences {
+		newId, ok := report.Sheets[ref.SourceSheetId]
+		if !ok {
+			report.Skipped = append(report.Skipped, RestoreSkip{Type: "crossSheetReference", SheetId: sheet.Id, Name: ref.Name, SourceSheetId: ref.SourceSheetId})
+			skippedRefs[ref.Name] = true
+			continue
+		}
+		ref.SourceSheetId = newId
+		ref.StartRowId, ref.EndRowId = report.Rows[ref.StartRowId], report.Rows[ref.EndRowId]
+		ref.StartColumnId, ref.EndColumnId = report.Columns[ref.StartColumnId], report.Columns[ref.EndColumnId]
+		if _, err := r.Client.CreateCrossSheetReference(sheetId, ref); err != nil {
+			return err
+		}
+	}
+	// Formulas using a skipped reference are left out and their cells keep their values
+	skippedColumns := map[int64]bool{}
+	for _, column := range sheet.Columns {
+		if column.Formula == "" {
+			continue
+		}
+		if usesReference(column.Formula, skippedRefs) {
+			report.warn("formula of column %s of sheet %s was not restored: it uses a cross-sheet reference outside of the backup", column.Title, sheet.Name)
+			skippedColumns[column.Id] = true
+			continue
+		}
+		formula := column.Formula
+		if _, err := r.Client.UpdateColumn(sheetId, report.Columns[column.Id], ColumnUpdate{Formula: &formula}); err != nil {
+			return err
+		}
+	}
+
+	var updates []Row
+	var links []InboundLink
+	for _, row := range sheet.Rows {
+		update := Row{Id: report.Rows[row.Id]}
+		for _, cell := range row.Cells {
+			column, err := sheet.GetColumnById(cell.ColumnId)
+			if err != nil || column.SystemColumnType != "" {
+				continue
+			}
+			columnId := report.Columns[cell.ColumnId]
+			if skippedColumns[column.Id] || usesReference(cell.Formula, skippedRefs) {
+				update.Cells = append(update.Cells, Cell{ColumnId: columnId, Value: cell.Value, Format: cell.Format})
+				continue
+			}
+			if column.Formula != "" {
+				continue
+			}
+			switch {
+			case cell.LinkInFromCell != nil:
+				source, ok := r.remapLink(report, *cell.LinkInFromCell)
+				if !ok {
+					report.Skipped = append(report.Skipped, RestoreSkip{Type: "cellLink", SheetId: sheet.Id, RowId: row.Id, ColumnId: cell.ColumnId, SourceSheetId: cell.LinkInFromCell.SheetId})
+					continue
+				}
+				links = append(links, InboundLink{RowId: update.Id, ColumnId: columnId, Source: source})
+			case cell.Formula != "":
+				update.Cells = append(update.Cells, Cell{ColumnId: columnId, Formula: cell.Formula, Format: cell.Format})
+			case isObjectHyperlink(cell.Hyperlink):
+				hyperlink := r.remapHyperlink(report, sheet, *cell.Hyperlink)
+				update.Cells = append(update.Cells, Cell{ColumnId: columnId, Value: cell.Value, Hyperlink: &hyperlink, Format: cell.Format})
+			case cell.ObjectValue != nil && cell.ObjectValue.ObjectType == ObjectTypePredecessorList:
+				predecessors := make([]Predecessor, len(cell.ObjectValue.Predecessors))
+				for i, p := range cell.ObjectValue.Predecessors {
+					p.RowId, p.RowNumber = report.Rows[p.RowId], 0
+					predecessors[i] = p
+				}
+				update.Cells = append(update.Cells, NewPredecessorCell(columnId, predecessors...))
+			}
+		}
+		if len(update.Cells) > 0 {
+			updates = append(updates, update)
+		}
+	}
+	if len(updates) > 0 {
+		writer := r.Client.NewBulkWriter(sheetId)
+		if _, err := writer.UpdateRows(updates); err != nil {
+			return err
+		}
+	}
+	if len(links) > 0 {
+		if _, err := r.Client.CreateCellLinks(sheetId, links); err != nil {
+			return err
+		}
+	}
+
+	// Row discussions can be listed both with the sheet and with their row
+	restored := map[int64]bool{}
+	for _, discussion := range sheet.Discussions {
+		rowId := int64(0)
+		if discussion.ParentType == "ROW" {
+			rowId = report.Rows[discussion.ParentId]
+		}
+		if err := r.restoreDiscussion(sheetId, rowId, discussion); err != nil {
+			return err
+		}
+		if discussion.Id != 0 {
+			restored[discussion.Id] = true
+		}
+	}
+	for _, row := range sheet.Rows {
+		for _, discussion := range row.Discussions {
+			if restored[discussion.Id] {
+				continue
+			}
+			if err := r.restoreDiscussion(sheetId, report.Rows[row.Id], discussion); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Return the link pointing to the restored source cell, or false if the source sheet is not
+// in the backup
+func (r *Restore) remapLink(report *RestoreReport, link CellLink) (CellLink, bool) {
+	newId, ok := report.Sheets[link.SheetId]
+	if !ok {
+		return CellLink{}, false
+	}
+	return CellLink{SheetId: newId, RowId: report.Rows[link.RowId], ColumnId: report.Columns[link.ColumnId]}, true
+}
+
+func (r *Restore) remapHyperlink(report *RestoreReport, sheet *Sheet, hyperlink Hyperlink) Hyperlink {
+	if hyperlink.SheetId != 0 {
+		if newId, ok := report.Sheets[hyperlink.SheetId]; ok {
+			return Hyperlink{SheetId: newId}
+		}
+	}
+	report.warn("hyperlink in sheet %s points to an object outside of the backup", sheet.Name)
+	return Hyperlink{SheetId: hyperlink.SheetId, ReportId: hyperlink.ReportId, SightId: hyperlink.SightId}
+}
+
+func (r *Restore) restoreDiscussion(sheetId int64, rowId int64, discussion Discussion) error {
+	if len(discussion.Comments) == 0 {
+		return nil
+	}
+	var created *Discussion
+	var err error
+	if rowId == 0 {
+		created, err = r.Client.CreateDiscussion(sheetId, discussion.Comments[0].Text)
+	} else {
+		created, err = r.Client.CreateRowDiscussion(sheetId, rowId, discussion.Comments[0].Text)
+	}
+	if err != nil {
+		return err
+	}
+	for _, comment := range discussion.Comments[1:] {
+		if _, err := r.Client.AddComment(sheetId, created.Id, comment.Text); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (report *RestoreReport) warn(format string, a ...interface{}) {
+	report.Warnings = append(report.Warnings, fmt.Sprintf(format, a...))
+}
+
+// Return true if the formula uses one of the named cross-sheet references
+func usesReference(formula string, names map[string]bool) bool {
+	if formula == "" || len(names) == 0 {
+		return false
+	}
+	_, refs, _ := scanFormula(formula)
+	for _, ref := range refs {
+		if names[ref] {
+			return true
+		}
+	}
+	return false
+}
+
+func isObjectHyperlink(h *Hyperlink) bool {
+	return h != nil && (h.SheetId != 0 || h.ReportId != 0 || h.SightId != 0)
+}
@@ -0,0 +1,282 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+)
+
+const restoreSheetA = `{"id":10,"name":"Plan","columns":[
+	{"id":100,"title":"Task","type":"TEXT_NUMBER","primary":true},
+	{"id":101,"title":"Depends on","type":"PREDECESSOR"},
+	{"id":102,"title":"See","type":"TEXT_NUMBER"},
+	{"id":103,"title":"Created","type":"DATETIME","systemColumnType":"CREATED_DATE"}],
+	"rows":[
+	{"id":1000,"rowNumber":1,"cells":[{"columnId":100,"value":"Phase"},{"columnId":103,"value":"2026-10-19T10:00:00Z"}]},
+	{"id":1001,"rowNumber":2,"parentId":1000,"cells":[{"columnId":100,"value":"Step","format":",,1"},
+		{"columnId":101,"value":"1","objectValue":{"objectType":"PREDECESSOR_LIST","predecessors":[{"rowId":1000,"rowNumber":1,"type":"FS"}]}}]},
+	{"id":1002,"rowNumber":3,"cells":[{"columnId":100,"formula":"=COUNT([Task]1:[Task]2)","value":2},
+		{"columnId":102,"value":"Totals","hyperlink":{"sheetId":11,"url":"https://app.smartsheet.com/sheets/x"}}]}],
+	"discussions":[{"id":7,"parentType":"SHEET","parentId":10,"comments":[{"text":"Kickoff"},{"text":"Agreed"}]}]}`
+
+const restoreSheetB = `{"id":11,"name":"Totals","columns":[
+	{"id":200,"title":"Source","type":"TEXT_NUMBER","primary":true},
+	{"id":201,"title":"Count","type":"TEXT_NUMBER","formula":"=COUNT({A Tasks})"}],
+	"crossSheetReferences":[{"id":8,"name":"A Tasks","sourceSheetId":10,"startColumnId":100,"endColumnId":100,"status":"OK"}],
+	"rows":[{"id":2000,"rowNumber":1,"cells":[{"columnId":200,"value":"Phase","linkInFromCell":{"sheetId":10,"rowId":1000,"columnId":100,"status":"OK"}},{"columnId":201,"value":3}]}]}`
+
+type restoreServer struct {
+	sync.Mutex
+	nextId   int64
+	requests map[string][]interface{}
+}
+
+func (s *restoreServer) id() int64 {
+	s.nextId++
+	return s.nextId
+}
+
+func (s *restoreServer) handle(t *testing.T) http.HandlerFunc {
+	collection := regexp.MustCompile(`^/(workspaces|folders|sheets)/\d+/(folders|sheets|rows|crosssheetreferences|discussions)$`)
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.Lock()
+		defer s.Unlock()
+		var body interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		key := r.Method + " " + r.URL.Path
+		s.requests[key] = append(s.requests[key], body)
+		var result interface{} = map[string]interface{}{"id": s.id()}
+		switch {
+		case key == "POST /workspaces", regexp.MustCompile(`comments$|columns/\d+$`).MatchString(r.URL.Path):
+		case collection.MatchString(r.URL.Path) && r.URL.Path[len(r.URL.Path)-7:] == "/sheets":
+			columns := body.(map[string]interface{})["columns"].([]interface{})
+			for _, c := range columns {
+				c.(map[string]interface{})["id"] = s.id()
+			}
+			result = map[string]interface{}{"id": s.id(), "columns": columns}
+		case collection.MatchString(r.URL.Path) && r.URL.Path[len(r.URL.Path)-5:] == "/rows":
+			rows := body.([]interface{})
+			for _, row := range rows {
+				if r.Method == "POST" {
+					row.(map[string]interface{})["id"] = s.id()
+				}
+			}
+			result = rows
+		case collection.MatchString(r.URL.Path):
+		default:
+			t.Errorf("unexpected request %s", key)
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"message": "SUCCESS", "resultCode": 0, "result": result})
+	}
+}
+
+func TestRestore_FromBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "restore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	manifest := BackupManifest{WorkspaceId: 1, WorkspaceName: "Golden", Items: []BackupItem{
+		{Type: ItemSheet, Id: 10, Name: "Plan", Path: "Plan", File: "sheets/10.json"},
+		{Type: ItemFolder, Id: 2, Name: "Plans/2026", Path: `Plans\/2026`},
+		{Type: ItemSheet, Id: 11, Name: "Totals", Path: `Plans\/2026/Totals`, ParentId: 2, File: "sheets/11.json"},
+		{Type: ItemSight, Id: 30, Name: "Status", Path: "Status", File: "sights/30.json"},
+		{Type: ItemSheet, Id: 12, Name: "Unchanged", Path: "Unchanged", File: "sheets/12.json", Unchanged: true},
+	}}
+	data, _ := json.Marshal(manifest)
+	target := dirTarget(dir)
+	assert.NoError(t, writeBytes(target, backupManifestFile, data))
+	assert.NoError(t, writeBytes(target, "sheets/10.json", []byte(restoreSheetA)))
+	assert.NoError(t, writeBytes(target, "sheets/11.json", []byte(restoreSheetB)))
+
+	server := &restoreServer{nextId: 5000, requests: map[string][]interface{}{}}
+	client, done := testServer(server.handle(t))
+	defer done()
+	restore := client.NewRestore()
+	restore.WorkspaceName = "Client A"
+	report, err := restore.FromBackup(filepath.Clean(dir))
+	assert.NoError(t, err)
+
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "Client A"}}, server.requests["POST /workspaces"])
+	workspace := report.WorkspaceId
+	folder := report.Folders[2]
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "Plans/2026"}}, server.requests[fmt.Sprintf("POST /workspaces/%d/folders", workspace)])
+	assert.Len(t, server.requests[fmt.Sprintf("POST /workspaces/%d/sheets", workspace)], 1)
+	assert.Len(t, server.requests[fmt.Sprintf("POST /folders/%d/sheets", folder)], 1)
+	assert.Len(t, report.Rows, 4)
+	assert.Len(t, report.Columns, 6)
+	assert.Equal(t, []string{"sight Status was not restored", "sheet Unchanged was not restored: sheets/12.json is not in the backup"}, report.Warnings)
+
+	// Rows are added level by level, children under their restored parent
+	planId, totalsId := report.Sheets[10], report.Sheets[11]
+	adds := server.requests[fmt.Sprintf("POST /sheets/%d/rows", planId)]
+	assert.Len(t, adds, 2)
+	top := adds[0].([]interface{})
+	assert.Len(t, top, 2)
+	assert.Equal(t, []interface{}{map[string]interface{}{"columnId": float64(report.Columns[100]), "value": "Phase"}}, top[0].(map[string]interface{})["cells"])
+	assert.Equal(t, []interface{}{}, top[1].(map[string]interface{})["cells"])
+	child := adds[1].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(report.Rows[1000]), child["parentId"])
+
+	// Formulas, hyperlinks and predecessors are written once every sheet exists
+	updates := server.requests[fmt.Sprintf("PUT /sheets/%d/rows", planId)][0].([]interface{})
+	expected := fmt.Sprintf(`[
+		{"id":%d,"cells":[{"columnId":%d,"objectValue":{"objectType":"PREDECESSOR_LIST","predecessors":[{"rowId":%d,"type":"FS"}]}}]},
+		{"id":%d,"cells":[{"columnId":%d,"formula":"=COUNT([Task]1:[Task]2)"},{"columnId":%d,"value":"Totals","hyperlink":{"sheetId":%d}}]}]`,
+		report.Rows[1001], report.Columns[101], report.Rows[1000], report.Rows[1002], report.Columns[100], report.Columns[102], totalsId)
+	actual, _ := json.Marshal(updates)
+	assert.JSONEq(t, expected, string(actual))
+
+	refs := server.requests[fmt.Sprintf("POST /sheets/%d/crosssheetreferences", totalsId)]
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "A Tasks", "sourceSheetId": float64(planId),
+		"startColumnId": float64(report.Columns[100]), "endColumnId": float64(report.Columns[100])}}, refs)
+	assert.Equal(t, []interface{}{map[string]interface{}{"formula": "=COUNT({A Tasks})"}},
+		server.requests[fmt.Sprintf("PUT /sheets/%d/columns/%d", totalsId, report.Columns[201])])
+	links, _ := json.Marshal(server.requests[fmt.Sprintf("PUT /sheets/%d/rows", totalsId)])
+	assert.JSONEq(t, fmt.Sprintf(`[[{"id":%d,"cells":[{"columnId":%d,"value":null,"linkInFromCell":{"sheetId":%d,"rowId":%d,"columnId":%d}}]}]]`,
+		report.Rows[2000], report.Columns[200], planId, report.Rows[1000], report.Columns[100]), string(links))
+
+	assert.Equal(t, []interface{}{map[string]interface{}{"comment": map[string]interface{}{"text": "Kickoff"}}},
+		server.requests[fmt.Sprintf("POST /sheets/%d/discussions", planId)])
+	assert.Len(t, server.requests, 12)
+}
+
+func TestRestore_ReferencesOutsideBackup(t *testing.T) {
+	dir, err := ioutil.TempDir("", "restore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	manifest := BackupManifest{WorkspaceId: 1, WorkspaceName: "Golden", Items: []BackupItem{
+		{Type: ItemSheet, Id: 11, Name: "Totals", Path: "Totals", File: "sheets/11.json"},
+	}}
+	data, _ := json.Marshal(manifest)
+	target := dirTarget(dir)
+	assert.NoError(t, writeBytes(target, backupManifestFile, data))
+	// Sheet 11 links to and references sheet 10, which is not in this backup
+	assert.NoError(t, writeBytes(target, "sheets/11.json", []byte(restoreSheetB)))
+
+	server := &restoreServer{nextId: 5000, requests: map[string][]interface{}{}}
+	client, done := testServer(server.handle(t))
+	defer done()
+	restore := client.NewRestore()
+	restore.WorkspaceId = 3
+	report, err := restore.FromBackup(dir)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []RestoreSkip{
+		{Type: "crossSheetReference", SheetId: 11, Name: "A Tasks", SourceSheetId: 10},
+		{Type: "cellLink", SheetId: 11, RowId: 2000, ColumnId: 200, SourceSheetId: 10},
+	}, report.Skipped)
+	totalsId := report.Sheets[11]
+	assert.Empty(t, server.requests[fmt.Sprintf("POST /sheets/%d/crosssheetreferences", totalsId)])
+	// The column formula uses the skipped reference, so its cells keep their values instead
+	assert.Empty(t, server.requests[fmt.Sprintf("PUT /sheets/%d/columns/%d", totalsId, report.Columns[201])])
+	assert.Equal(t, []string{"formula of column Count of sheet Totals was not restored: it uses a cross-sheet reference outside of the backup"}, report.Warnings)
+	updates, _ := json.Marshal(server.requests[fmt.Sprintf("PUT /sheets/%d/rows", totalsId)])
+	assert.JSONEq(t, fmt.Sprintf(`[[{"id":%d,"cells":[{"columnId":%d,"value":3}]}]]`, report.Rows[2000], report.Columns[201]), string(updates))
+	// The linked cell keeps its value
+	added := server.requests[fmt.Sprintf("POST /sheets/%d/rows", totalsId)][0].([]interface{})
+	cells, _ := json.Marshal(added[0].(map[string]interface{})["cells"])
+	assert.JSONEq(t, fmt.Sprintf(`[{"columnId":%d,"value":"Phase"}]`, report.Columns[200]), string(cells))
+}
+
+func TestRestore_ChildRowsOfSeveralParents(t *testing.T) {
+	dir, err := ioutil.TempDir("", "restore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	manifest := BackupManifest{WorkspaceId: 1, WorkspaceName: "Golden", Items: []BackupItem{
+		{Type: ItemSheet, Id: 10, Name: "Plan", Path: "Plan", File: "sheets/10.json"},
+	}}
+	data, _ := json.Marshal(manifest)
+	target := dirTarget(dir)
+	assert.NoError(t, writeBytes(target, backupManifestFile, data))
+	assert.NoError(t, writeBytes(target, "sheets/10.json", []byte(`{"id":10,"name":"Plan",
+		"columns":[{"id":100,"title":"Task","type":"TEXT_NUMBER","primary":true}],
+		"rows":[{"id":1,"cells":[{"columnId":100,"value":"Design"}]},
+		{"id":2,"parentId":1,"cells":[{"columnId":100,"value":"Sketch"}]},
+		{"id":3,"parentId":1,"cells":[{"columnId":100,"value":"Review"}]},
+		{"id":4,"cells":[{"columnId":100,"value":"Build"}]},
+		{"id":5,"parentId":4,"cells":[{"columnId":100,"value":"Code"}]}]}`)))
+
+	server := &restoreServer{nextId: 5000, requests: map[string][]interface{}{}}
+	client, done := testServer(server.handle(t))
+	defer done()
+	restore := client.NewRestore()
+	restore.WorkspaceId = 3
+	report, err := restore.FromBackup(dir)
+	assert.NoError(t, err)
+
+	// Every add request holds rows of a single location: the top level, then each parent's children
+	adds := server.requests[fmt.Sprintf("POST /sheets/%d/rows", report.Sheets[10])]
+	assert.Len(t, adds, 3)
+	var locations [][]interface{}
+	for _, add := range adds {
+		var parents []interface{}
+		for _, row := range add.([]interface{}) {
+			parents = append(parents, row.(map[string]interface{})["parentId"])
+		}
+		locations = append(locations, parents)
+	}
+	assert.Equal(t, [][]interface{}{
+		{nil, nil},
+		{float64(report.Rows[1]), float64(report.Rows[1])},
+		{float64(report.Rows[4])},
+	}, locations)
+	assert.Len(t, report.Rows, 5)
+	assert.NotEqual(t, report.Rows[2], report.Rows[5])
+}
+
+func TestRestore_SiblingFoldersWithTheSameName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "restore")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	manifest := BackupManifest{WorkspaceId: 1, WorkspaceName: "Golden", Items: []BackupItem{
+		{Type: ItemFolder, Id: 2, Name: "Q1", Path: "Q1"},
+		{Type: ItemFolder, Id: 3, Name: "Q1", Path: "Q1"},
+		{Type: ItemSheet, Id: 10, Name: "Plan", Path: "Q1/Plan", ParentId: 2, File: "sheets/10.json"},
+		{Type: ItemFolder, Id: 4, Name: "Notes", Path: "Q1/Notes", ParentId: 3},
+		{Type: ItemSheet, Id: 11, Name: "Minutes", Path: "Q1/Notes/Minutes", ParentId: 4, File: "sheets/11.json"},
+	}}
+	data, _ := json.Marshal(manifest)
+	target := dirTarget(dir)
+	assert.NoError(t, writeBytes(target, backupManifestFile, data))
+	for _, id := range []int{10, 11} {
+		sheet := fmt.Sprintf(`{"id":%d,"name":"Sheet","columns":[{"id":%d,"title":"Task","type":"TEXT_NUMBER","primary":true}]}`, id, id*10)
+		assert.NoError(t, writeBytes(target, fmt.Sprintf("sheets/%d.json", id), []byte(sheet)))
+	}
+
+	server := &restoreServer{nextId: 5000, requests: map[string][]interface{}{}}
+	client, done := testServer(server.handle(t))
+	defer done()
+	restore := client.NewRestore()
+	restore.WorkspaceId = 3
+	report, err := restore.FromBackup(dir)
+	assert.NoError(t, err)
+
+	// Items are restored into the folder they were backed up from, not the first folder of that name
+	assert.NotEqual(t, report.Folders[2], report.Folders[3])
+	assert.Len(t, server.requests["POST /workspaces/3/folders"], 2)
+	assert.Equal(t, []interface{}{map[string]interface{}{"name": "Notes"}}, server.requests[fmt.Sprintf("POST /folders/%d/folders", report.Folders[3])])
+	assert.Len(t, server.requests[fmt.Sprintf("POST /folders/%d/sheets", report.Folders[2])], 1)
+	assert.Len(t, server.requests[fmt.Sprintf("POST /folders/%d/sheets", report.Folders[4])], 1)
+}
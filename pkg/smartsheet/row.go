@@ -19,13 +19,15 @@ package smartsheet
 import (
 	"fmt"
 	"github.com/mitchellh/mapstructure"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type Row struct {
 	Id                int64        `json:"id,omitempty"`                // Row Id
 	SheetId           int64        `json:"sheetId,omitempty"`           // Parent sheet Id
-	AccessLevel       string       `json:"accessLevel,omitempty"`       // User's permission level on the sheet that contains the row
+	AccessLevel       AccessLevel  `json:"accessLevel,omitempty"`       // User's permission level on the sheet that contains the row
 	Attachments       []Attachment `json:"attachments,omitempty"`       // Array of Attachment objects. Only returned if the include query string parameter contains attachments.
 	Cells             []Cell       `json:"cells"`                       // Array of Cell objects belonging to the row
 	Columns           []Column     `json:"columns,omitempty"`           // Array of Column objects. Only returned if the Get Row include query string parameter contains columns.
@@ -44,8 +46,11 @@ type Row struct {
 	Permalink         string       `json:"permalink,omitempty"`         // URL that represents a direct link to the row in Smartsheet. Only returned if the include query string parameter contains rowPermalink.
 	Rownumber         int64        `json:"rownumber,omitempty"`         // Row int within the sheet (1-based - starts at 1)
 	Version           int64        `json:"version,omitempty"`           // Sheet version int that is incremented every time a sheet is modified
-	ToTop             bool         `json:"toTop,omitempty"`
-	ToBottom          bool         `json:"toBottom,omitempty"`
+	ToTop             bool         `json:"toTop,omitempty"`             // Adds or moves the row to the top of the sheet, or to the first child row of the parent when used with parentId
+	ToBottom          bool         `json:"toBottom,omitempty"`          // Adds or moves the row to the bottom of the sheet, or to the last child row of the parent when used with parentId
+	ParentId          int64        `json:"parentId,omitempty"`          // Adds or moves the row as a child of the row with this Id
+	SiblingId         int64        `json:"siblingId,omitempty"`         // Adds or moves the row next to the row with this Id
+	Above             bool         `json:"above,omitempty"`             // Used with siblingId. Places the row above the sibling instead of below it
 }
 
 // Return number of cells in the row
@@ -53,6 +58,19 @@ func (r Row) CellCount() int {
 	return len(r.Cells)
 }
 
+// Return Row object
+func (c Client) GetRow(sheetId int64, rowId int64) (*Row, error) {
+	var row Row
+	resp, err := c.get(fmt.Sprintf("%s/sheets/%d/rows/%d", apiEndpoint, sheetId, rowId))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &row); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &row, nil
+}
+
 // Return ResultObject object
 func (c Client) AddRow(sheetId int64, rows []Row) (*[]Row, error) {
 	var res ResultObject
@@ -67,3 +85,39 @@ func (c Client) AddRow(sheetId int64, rows []Row) (*[]Row, error) {
 	err = mapstructure.Decode(res.Result, &result)
 	return &result, nil
 }
+
+// Return updated Row objects
+func (c Client) UpdateRows(sheetId int64, rows []Row) (*[]Row, error) {
+	var result []Row
+	res := ResultObject{Result: &result}
+	resp, err := c.put(fmt.Sprintf("%s/sheets/%d/rows", apiEndpoint, sheetId), rows, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &result, nil
+}
+
+// Return Ids of the deleted rows
+func (c Client) DeleteRows(sheetId int64, rowIds []int64) (*[]int64, error) {
+	var result []int64
+	res := ResultObject{Result: &result}
+	resp, err := c.delete(fmt.Sprintf("%s/sheets/%d/rows?ids=%s&ignoreRowsNotFound=true", apiEndpoint, sheetId, joinIds(rowIds)))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &result, nil
+}
+
+func joinIds(ids []int64) string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.FormatInt(id, 10)
+	}
+	return strings.Join(s, ",")
+}
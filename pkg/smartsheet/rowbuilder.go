@@ -0,0 +1,180 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RowBuilder builds a Row for a sheet, addressing cells by column title.
+// Errors are collected as the row is built and returned by Build.
+type RowBuilder struct {
+	sheet Sheet
+	row   Row
+	cells map[int64]int // column Id to index in row.Cells
+	errs  []string
+}
+
+// Return a RowBuilder bound to the sheet's columns
+func (s Sheet) NewRow() *RowBuilder {
+	return &RowBuilder{
+		sheet: s,
+		cells: map[int64]int{},
+	}
+}
+
+// Return a RowBuilder for an existing row, used to build row updates
+func (s Sheet) UpdateRow(rowId int64) *RowBuilder {
+	b := s.NewRow()
+	b.row.Id = rowId
+	return b
+}
+
+// Set the value of the cell in the named column
+func (b *RowBuilder) Value(title string, value interface{}) *RowBuilder {
+	if cell := b.cell(title); cell != nil {
+		cell.Value = value
+	}
+	return b
+}
+
+// Set the formula of the cell in the named column
+func (b *RowBuilder) Formula(title string, formula string) *RowBuilder {
+	if cell := b.cell(title); cell != nil {
+		cell.Formula = formula
+	}
+	return b
+}
+
+// Set a hyperlink on the cell in the named column. The cell value, if set, is used as the link text.
+func (b *RowBuilder) Hyperlink(title string, link Hyperlink) *RowBuilder {
+	if cell := b.cell(title); cell != nil {
+		cell.Hyperlink = &link
+	}
+	return b
+}
+
+// Link the cell in the named column to a cell in another sheet. The cell's value mirrors the linked cell's value.
+func (b *RowBuilder) CellLink(title string, link CellLink) *RowBuilder {
+	if cell := b.cell(title); cell != nil {
+		cell.LinkInFromCell = &link
+	}
+	return b
+}
+
+// Set the format descriptor of the cell in the named column
+func (b *RowBuilder) Format(title string, format string) *RowBuilder {
+	if cell := b.cell(title); cell != nil {
+		cell.Format = format
+	}
+	return b
+}
+
+// Set strict value parsing for the cell in the named column. Set to false for lenient parsing.
+func (b *RowBuilder) Strict(title string, strict bool) *RowBuilder {
+	if cell := b.cell(title); cell != nil {
+		cell.Strict = &strict
+	}
+	return b
+}
+
+// Allow the cell in the named column to hold a value outside of the column's validation (admin only).
+// Strict parsing is disabled for the cell, as required by the API.
+func (b *RowBuilder) OverrideValidation(title string) *RowBuilder {
+	if cell := b.cell(title); cell != nil {
+		strict := false
+		cell.OverrideValidation = true
+		cell.Strict = &strict
+	}
+	return b
+}
+
+// Set the format descriptor of the row
+func (b *RowBuilder) RowFormat(format string) *RowBuilder {
+	b.row.Format = format
+	return b
+}
+
+// Place the row at the top of the sheet, or as the first child when used with Parent
+func (b *RowBuilder) ToTop() *RowBuilder {
+	b.row.ToTop = true
+	return b
+}
+
+// Place the row at the bottom of the sheet, or as the last child when used with Parent
+func (b *RowBuilder) ToBottom() *RowBuilder {
+	b.row.ToBottom = true
+	return b
+}
+
+// Place the row as a child of the row with parentId
+func (b *RowBuilder) Parent(parentId int64) *RowBuilder {
+	b.row.ParentId = parentId
+	return b
+}
+
+// Place the row next to the row with siblingId, above it if above is true
+func (b *RowBuilder) Sibling(siblingId int64, above bool) *RowBuilder {
+	b.row.SiblingId = siblingId
+	b.row.Above = above
+	return b
+}
+
+// Return the built Row, or an error listing every problem found while building it
+func (b *RowBuilder) Build() (Row, error) {
+	errs := append([]string(nil), b.errs...)
+	if b.row.ToTop && b.row.ToBottom {
+		errs = append(errs, "row cannot be placed both at the top and at the bottom")
+	}
+	if b.row.SiblingId != 0 && (b.row.ToTop || b.row.ToBottom || b.row.ParentId != 0) {
+		errs = append(errs, "sibling location cannot be combined with top, bottom or parent")
+	}
+	for _, cell := range b.row.Cells {
+		if cell.LinkInFromCell != nil && (cell.Value != nil || cell.Formula != "") {
+			errs = append(errs, fmt.Sprintf("cell in column %d cannot have a cell link and a value or formula", cell.ColumnId))
+		}
+	}
+	if len(errs) > 0 {
+		return Row{}, fmt.Errorf("invalid row: %s", strings.Join(errs, "; "))
+	}
+	row := b.row
+	row.Cells = append([]Cell(nil), b.row.Cells...)
+	return row, nil
+}
+
+func (b *RowBuilder) cell(title string) *Cell {
+	column, err := b.sheet.GetColumnByName(title)
+	if err != nil {
+		b.errs = append(b.errs, err.Error())
+		return nil
+	}
+	if column.SystemColumnType != "" {
+		b.errs = append(b.errs, fmt.Sprintf("column %s is a %s system column", title, column.SystemColumnType))
+		return nil
+	}
+	if column.LockedForUser {
+		b.errs = append(b.errs, fmt.Sprintf("column %s is locked", title))
+		return nil
+	}
+	if i, ok := b.cells[column.Id]; ok {
+		return &b.row.Cells[i]
+	}
+	b.cells[column.Id] = len(b.row.Cells)
+	b.row.Cells = append(b.row.Cells, Cell{ColumnId: column.Id})
+	return &b.row.Cells[len(b.row.Cells)-1]
+}
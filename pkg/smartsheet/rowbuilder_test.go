@@ -0,0 +1,81 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func testBuilderSheet() Sheet {
+	return Sheet{
+		Columns: []Column{
+			{Id: 1, Title: "Task"},
+			{Id: 2, Title: "Status"},
+			{Id: 3, Title: "Created", SystemColumnType: "CREATED_DATE"},
+			{Id: 4, Title: "Budget", Locked: true, LockedForUser: true},
+		},
+	}
+}
+
+func TestRowBuilder_Build(t *testing.T) {
+	row, err := testBuilderSheet().NewRow().
+		Value("Task", "Write docs").
+		Value("Status", "Open").
+		Format("Status", ",,1,,,,,,,,,,,,,").
+		Strict("Status", false).
+		Parent(10).
+		ToTop().
+		Build()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), row.ParentId)
+	assert.True(t, row.ToTop)
+	assert.Len(t, row.Cells, 2)
+	assert.Equal(t, int64(1), row.Cells[0].ColumnId)
+	assert.Equal(t, "Write docs", row.Cells[0].Value)
+	assert.Equal(t, int64(2), row.Cells[1].ColumnId)
+	assert.Equal(t, ",,1,,,,,,,,,,,,,", row.Cells[1].Format)
+	assert.False(t, *row.Cells[1].Strict)
+}
+
+func TestRowBuilder_OverrideValidation(t *testing.T) {
+	row, err := testBuilderSheet().UpdateRow(7).Value("Status", "Unknown").OverrideValidation("Status").Build()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), row.Id)
+	assert.True(t, row.Cells[0].OverrideValidation)
+	assert.False(t, *row.Cells[0].Strict)
+}
+
+func TestRowBuilder_BuildErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		builder *RowBuilder
+	}{
+		{"unknown column", testBuilderSheet().NewRow().Value("Missing", 1)},
+		{"system column", testBuilderSheet().NewRow().Value("Created", "2020-01-01")},
+		{"locked column", testBuilderSheet().NewRow().Value("Budget", 100)},
+		{"top and bottom", testBuilderSheet().NewRow().ToTop().ToBottom()},
+		{"sibling and parent", testBuilderSheet().NewRow().Parent(1).Sibling(2, true)},
+		{"link and value", testBuilderSheet().NewRow().Value("Task", "x").CellLink("Task", CellLink{ColumnId: 9})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.builder.Build()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type SearchResult struct {
+	Results    []SearchResultItem `json:"results"`    // Array of SearchResultItem objects
+	TotalCount int                `json:"totalCount"` // Total number of search results
+}
+
+type SearchResultItem struct {
+	ObjectType           ItemType           `json:"objectType"`                     // Type of the matching object: attachment, discussion, folder, report, row, sheet, sight, summaryField, template or workspace
+	ObjectId             int64              `json:"objectId"`                       // Id of the matching object
+	ParentObjectId       int64              `json:"parentObjectId,omitempty"`       // Id of the object that contains the match, for instance the sheet of a row
+	ParentObjectName     string             `json:"parentObjectName,omitempty"`     // Name of the parent object
+	ParentObjectType     ItemType           `json:"parentObjectType,omitempty"`     // Type of the parent object
+	ParentObjectFavorite bool               `json:"parentObjectFavorite,omitempty"` // Indicates whether the parent object is a favorite. Only returned if the include parameter contains favoriteFlag
+	ContextData          []string           `json:"contextData,omitempty"`          // Additional information about the match, for instance the values of the row's primary column
+	Text                 string             `json:"text"`                           // Matched text, or the name of the matching object
+	Proofs               []SearchResultItem `json:"proofs,omitempty"`               // Matches within the object, for instance the rows of a matching sheet
+}
+
+// SearchOptions narrows a search
+type SearchOptions struct {
+	Scopes              []SearchScope // Kinds of content to search. Everything is searched if empty
+	ModifiedSince       time.Time     // Only return objects modified after this time, if set
+	PersonalWorkspace   bool          // Only search items in Home that are not in a workspace. Ignored by SearchSheet
+	IncludeFavoriteFlag bool          // Set ParentObjectFavorite on the results. Ignored by SearchSheet
+}
+
+// Return SearchResult object with the objects matching the query
+func (c Client) Search(query string, options *SearchOptions) (*SearchResult, error) {
+	return c.search(fmt.Sprintf("%s/search", apiEndpoint), query, options, true)
+}
+
+// Return SearchResult object with the rows, discussions and attachments of the sheet matching the query
+func (c Client) SearchSheet(sheetId int64, query string, options *SearchOptions) (*SearchResult, error) {
+	return c.search(fmt.Sprintf("%s/search/sheets/%d", apiEndpoint, sheetId), query, options, false)
+}
+
+func (c Client) search(path string, text string, options *SearchOptions, global bool) (*SearchResult, error) {
+	var result SearchResult
+	query := url.Values{}
+	query.Set("query", text)
+	if options != nil {
+		if len(options.Scopes) > 0 {
+			scopes := make([]string, len(options.Scopes))
+			for i := range options.Scopes {
+				scopes[i] = string(options.Scopes[i])
+			}
+			query.Set("scopes", strings.Join(scopes, ","))
+		}
+		if !options.ModifiedSince.IsZero() {
+			query.Set("modifiedSince", options.ModifiedSince.UTC().Format(time.RFC3339))
+		}
+		if global && options.PersonalWorkspace {
+			query.Set("location", "personalWorkspace")
+		}
+		if global && options.IncludeFavoriteFlag {
+			query.Set("include", "favoriteFlag")
+		}
+	}
+	resp, err := c.get(withQuery(path, query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &result); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &result, nil
+}
+
+// Return the id of the sheet the result is or belongs to
+func (i SearchResultItem) SheetId() (int64, bool) {
+	if i.ObjectType == ItemSheet {
+		return i.ObjectId, true
+	}
+	if i.ParentObjectType == ItemSheet || (i.ObjectType == ItemRow && i.ParentObjectId != 0) {
+		return i.ParentObjectId, true
+	}
+	return 0, false
+}
+
+// Return the id of the row the result is, or of the row matched by its first row proof
+func (i SearchResultItem) RowId() (int64, bool) {
+	if i.ObjectType == ItemRow {
+		return i.ObjectId, true
+	}
+	for _, proof := range i.Proofs {
+		if proof.ObjectType == ItemRow {
+			return proof.ObjectId, true
+		}
+	}
+	return 0, false
+}
+
+// Return the Sheet object the search result is or belongs to
+func (c Client) GetSearchResultSheet(item SearchResultItem) (*Sheet, error) {
+	sheetId, ok := item.SheetId()
+	if !ok {
+		return nil, fmt.Errorf("%s %d is not in a sheet", item.ObjectType, item.ObjectId)
+	}
+	return c.GetSheet(strconv.FormatInt(sheetId, 10))
+}
+
+// Return the Row object the search result refers to
+func (c Client) GetSearchResultRow(item SearchResultItem) (*Row, error) {
+	sheetId, ok := item.SheetId()
+	rowId, hasRow := item.RowId()
+	if !ok || !hasRow {
+		return nil, fmt.Errorf("%s %d does not refer to a row", item.ObjectType, item.ObjectId)
+	}
+	return c.GetRow(sheetId, rowId)
+}
@@ -0,0 +1,89 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestClient_Search(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/search":
+			query := r.URL.Query()
+			assert.Equal(t, "budget review", query.Get("query"))
+			assert.Equal(t, "cellData,sheetNames", query.Get("scopes"))
+			assert.Equal(t, "2026-10-01T00:00:00Z", query.Get("modifiedSince"))
+			assert.Equal(t, "favoriteFlag", query.Get("include"))
+			_, _ = w.Write([]byte(`{"totalCount":2,"results":[
+				{"objectType":"row","objectId":1000,"parentObjectId":10,"parentObjectName":"Plan","parentObjectType":"sheet","parentObjectFavorite":true,"contextData":["Budget review"],"text":"Budget review"},
+				{"objectType":"sheet","objectId":11,"text":"Budget","proofs":[{"objectType":"row","objectId":2000,"text":"review"}]}
+			]}`))
+		case "/sheets/10/rows/1000":
+			_, _ = w.Write([]byte(`{"id":1000,"sheetId":10,"cells":[{"columnId":1,"value":"Budget review"}]}`))
+		case "/sheets/11":
+			_, _ = w.Write([]byte(`{"id":11,"name":"Budget"}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer done()
+	result, err := client.Search("budget review", &SearchOptions{
+		Scopes:              []SearchScope{SearchCellData, SearchSheetNames},
+		ModifiedSince:       time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		IncludeFavoriteFlag: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, result.TotalCount)
+	row := result.Results[0]
+	assert.Equal(t, ItemRow, row.ObjectType)
+	assert.True(t, row.ParentObjectFavorite)
+	assert.Equal(t, []string{"Budget review"}, row.ContextData)
+
+	found, err := client.GetSearchResultRow(row)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1000), found.Id)
+	sheet, err := client.GetSearchResultSheet(result.Results[1])
+	assert.NoError(t, err)
+	assert.Equal(t, "Budget", sheet.Name)
+	rowId, ok := result.Results[1].RowId()
+	assert.True(t, ok)
+	assert.Equal(t, int64(2000), rowId)
+
+	_, err = client.GetSearchResultRow(SearchResultItem{ObjectType: ItemWorkspace, ObjectId: 1})
+	assert.Error(t, err)
+}
+
+func TestClient_SearchSheet(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/search/sheets/10", r.URL.Path)
+		assert.Equal(t, "comments", r.URL.Query().Get("scopes"))
+		assert.Empty(t, r.URL.Query().Get("location"))
+		_, _ = w.Write([]byte(`{"totalCount":1,"results":[{"objectType":"discussion","objectId":7,"parentObjectId":10,"parentObjectType":"sheet","text":"Kickoff"}]}`))
+	})
+	defer done()
+	result, err := client.SearchSheet(10, "kickoff", &SearchOptions{Scopes: []SearchScope{SearchComments}, PersonalWorkspace: true})
+	assert.NoError(t, err)
+	sheetId, ok := result.Results[0].SheetId()
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), sheetId)
+	_, ok = result.Results[0].RowId()
+	assert.False(t, ok)
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"strings"
+)
+
+type ServerInfo struct {
+	FeatureInfo      FeatureInfo  `json:"featureInfo"`      // Feature-specific information
+	Formats          FormatTables `json:"formats"`          // The lookup tables used by format descriptors
+	SupportedLocales []string     `json:"supportedLocales"` // Array of strings representing all Smartsheet-supported locales
+}
+
+type FeatureInfo struct {
+	SymbolsVersion int `json:"symbolsVersion"` // Version of the symbols used by CHECKBOX and PICKLIST columns
+}
+
+type FormatTables struct {
+	Defaults           string       `json:"defaults"`           // The default format descriptor
+	Bold               []string     `json:"bold"`               // Possible bold values
+	Color              []string     `json:"color"`              // Color palette, as hex values. Used for text, background and taskbar colors
+	Currency           []Currency   `json:"currency"`           // Possible currencies
+	DateFormat         []string     `json:"dateFormat"`         // Possible date formats
+	DecimalCount       []string     `json:"decimalCount"`       // Possible decimal counts
+	FontFamily         []FontFamily `json:"fontFamily"`         // Possible font families
+	FontSize           []string     `json:"fontSize"`           // Possible font sizes
+	HorizontalAlign    []string     `json:"horizontalAlign"`    // Possible horizontal alignments
+	Italic             []string     `json:"italic"`             // Possible italic values
+	NumberFormat       []string     `json:"numberFormat"`       // Possible number formats
+	Strikethrough      []string     `json:"strikethrough"`      // Possible strikethrough values
+	TextWrap           []string     `json:"textWrap"`           // Possible text wrap values
+	ThousandsSeparator []string     `json:"thousandsSeparator"` // Possible thousands separator values
+	Underline          []string     `json:"underline"`          // Possible underline values
+	VerticalAlign      []string     `json:"verticalAlign"`      // Possible vertical alignments
+}
+
+type Currency struct {
+	Code   string `json:"code"`   // The ISO 4217 currency code, for instance EUR
+	Symbol string `json:"symbol"` // The currency symbol, for instance €
+}
+
+type FontFamily struct {
+	Name   string   `json:"name"`   // Name of the font family, for instance Arial
+	Traits []string `json:"traits"` // Platform-independent traits of the font family, for instance sans-serif
+}
+
+// Return ServerInfo object
+func (c Client) GetServerInfo() (*ServerInfo, error) {
+	var info ServerInfo
+	resp, err := c.get(fmt.Sprintf("%s/serverinfo", apiEndpoint))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &info); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &info, nil
+}
+
+// Return the default format
+func (t FormatTables) DefaultFormat() (Format, error) {
+	return ParseFormat(t.Defaults)
+}
+
+// Return the palette index of a color given as a hex value such as #FF0000
+func (t FormatTables) ColorIndex(hex string) (int, error) {
+	return lookupIndex(t.Color, hex, "color")
+}
+
+// Return the index of a font family by name
+func (t FormatTables) FontFamilyIndex(name string) (int, error) {
+	for i, font := range t.FontFamily {
+		if strings.EqualFold(font.Name, name) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("font family %s is not available", name)
+}
+
+// Return the index of a font size, for instance "10"
+func (t FormatTables) FontSizeIndex(size string) (int, error) {
+	return lookupIndex(t.FontSize, size, "font size")
+}
+
+// Return the index of a currency by ISO 4217 code
+func (t FormatTables) CurrencyIndex(code string) (int, error) {
+	for i, currency := range t.Currency {
+		if strings.EqualFold(currency.Code, code) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("currency %s is not available", code)
+}
+
+// Return the index of a date format, for instance MMMM_D_YYYY
+func (t FormatTables) DateFormatIndex(format string) (int, error) {
+	return lookupIndex(t.DateFormat, format, "date format")
+}
+
+// Return the hex value of the format's text, background and taskbar colors. Unset colors are empty.
+func (t FormatTables) Colors(f Format) (color, background, taskbar string, err error) {
+	lookup := func(i *int) (string, error) {
+		if i == nil {
+			return "", nil
+		}
+		if *i >= len(t.Color) {
+			return "", fmt.Errorf("color index %d is not in the palette", *i)
+		}
+		return t.Color[*i], nil
+	}
+	if color, err = lookup(f.Color); err != nil {
+		return
+	}
+	if background, err = lookup(f.BackgroundColor); err != nil {
+		return
+	}
+	taskbar, err = lookup(f.TaskbarColor)
+	return
+}
+
+func lookupIndex(table []string, value string, name string) (int, error) {
+	for i, v := range table {
+		if strings.EqualFold(v, value) {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("%s %s is not available", name, value)
+}
@@ -25,7 +25,7 @@ type Sheet struct {
 	Id                         int64                 `json:"id"`                         //Sheet Id
 	FromId                     int64                 `json:"fromId"`                     // The Id of the template from which to create the sheet. This attribute can be specified in a request, but is never present in a response.
 	OwnerId                    int64                 `json:"ownerId"`                    // User Id of the sheet owner
-	AccessLevel                string                `json:"accessLevel"`                //User's permissions on the sheet
+	AccessLevel                AccessLevel           `json:"accessLevel"`                //User's permissions on the sheet
 	Attachments                []Attachment          `json:"attachments"`                //Array of Attachment objects. Only returned if the include query string parameter contains Attachments.
 	Columns                    []Column              `json:"columns"`                    // Array of Column objects
 	CreatedAt                  string                `json:"createdAt"`                  // Time that the sheet was created
@@ -116,6 +116,9 @@ func (c Client) UpdateSheet(id string, sheet Sheet) (*ResultObject, error) {
 
 // Return ResultObject object
 func (c Client) CreateSheet(sheet Sheet) (*ResultObject, error) {
+	if err := sheet.validateColumnTypes(); err != nil {
+		return nil, err
+	}
 	var res ResultObject
 	resp, err := c.post(fmt.Sprintf("%s/sheets", apiEndpoint), sheet, nil)
 	if err != nil {
@@ -129,7 +132,10 @@ func (c Client) CreateSheet(sheet Sheet) (*ResultObject, error) {
 }
 
 // Return ResultObject object
-func (c Client) CreateSheetInFolder(folderId int, sheet Sheet) (*ResultObject, error) {
+func (c Client) CreateSheetInFolder(folderId int64, sheet Sheet) (*ResultObject, error) {
+	if err := sheet.validateColumnTypes(); err != nil {
+		return nil, err
+	}
 	var res ResultObject
 	resp, err := c.post(fmt.Sprintf("%s/folders/%d/sheets", apiEndpoint, folderId), sheet, nil)
 	if err != nil {
@@ -143,7 +149,10 @@ func (c Client) CreateSheetInFolder(folderId int, sheet Sheet) (*ResultObject, e
 }
 
 // Return ResultObject object
-func (c Client) CreateSheetInWorkspace(workspaceId int, sheet Sheet) (*ResultObject, error) {
+func (c Client) CreateSheetInWorkspace(workspaceId int64, sheet Sheet) (*ResultObject, error) {
+	if err := sheet.validateColumnTypes(); err != nil {
+		return nil, err
+	}
 	var res ResultObject
 	resp, err := c.post(fmt.Sprintf("%s/workspaces/%d/sheets", apiEndpoint, workspaceId), sheet, nil)
 	if err != nil {
@@ -156,6 +165,15 @@ func (c Client) CreateSheetInWorkspace(workspaceId int, sheet Sheet) (*ResultObj
 	return &res, nil
 }
 
+func (s Sheet) validateColumnTypes() error {
+	for _, column := range s.Columns {
+		if err := column.validateTypes(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 type SheetUserSettings struct {
 	CriticalPathEnabled bool // Does this user have "Show Critical Path" turned on for this sheet? NOTE: This setting only has an effect on project sheets with dependencies enabled.
 	DisplaySummaryTasks bool // Does this user have "Display Summary Tasks" turned on for this sheet? Applies only to sheets where "Calendar View" has been configured.
@@ -186,9 +204,9 @@ type SummaryField struct {
 	ModifiedBy     User            `json:"modifiedBy"`     // User object containing name and email of the summaryField's author
 	ObjectValue    ObjectValue     `json:"objectValue"`    // Required for date and contact fields
 	Options        []string        `json:"options"`        // When applicable for PICKLIST column type. Array of the options available for the field
-	Symbol         string          `json:"symbol"`         // When applicable for PICKLIST column type. See Symbol Columns.
+	Symbol         Symbol          `json:"symbol"`         // When applicable for PICKLIST column type. See Symbol Columns.
 	Title          string          `json:"title"`          // Arbitrary name, must be unique within summary
-	Type           string          `json:"type"`           // One of:
+	Type           ColumnType      `json:"type"`           // One of:
 	Validation     bool            `json:"validation"`     // Indicates whether summary field values are restricted to the type
 }
 
@@ -204,12 +222,27 @@ type ProjectSettings struct {
 }
 
 type CrossSheetReference struct {
-	Id            int64  `json:"id"`            // Cross-sheet reference Id, guaranteed unique within referencing sheet.
-	EndColumnId   int64  `json:"endColumnId"`   // Defines ending edge of range when specifying one or more columns. To specify an entire column, omit the startRowId and endRowId parameters.
-	EndRowId      int64  `json:"endRowId"`      // Defines ending edge of range when specifying one or more rows. To specify an entire row, omit the startColumnId and endColumnId parameters.
-	SourceSheetId int64  `json:"sourceSheetId"` // Sheet Id of source sheet.
-	StartColumnId int64  `json:"startColumnId"` // Defines beginning edge of range when specifying one or more columns. To specify an entire column, omit the startRowId and endRowId parameters.
-	StartRowId    int64  `json:"startRowId"`    // Defines beginning edge of range when specifying one or more rows. To specify an entire row, omit the startColumnId and endColumnId parameters.
-	Name          string `json:"name"`          // Friendly name of reference. Auto-generated unless specified in Create Cross-sheet References.
-	Status        string `json:""`
+	Id            int64  `json:"id,omitempty"`            // Cross-sheet reference Id, guaranteed unique within referencing sheet.
+	EndColumnId   int64  `json:"endColumnId,omitempty"`   // Defines ending edge of range when specifying one or more columns. To specify an entire column, omit the startRowId and endRowId parameters.
+	EndRowId      int64  `json:"endRowId,omitempty"`      // Defines ending edge of range when specifying one or more rows. To specify an entire row, omit the startColumnId and endColumnId parameters.
+	SourceSheetId int64  `json:"sourceSheetId,omitempty"` // Sheet Id of source sheet.
+	StartColumnId int64  `json:"startColumnId,omitempty"` // Defines beginning edge of range when specifying one or more columns. To specify an entire column, omit the startRowId and endRowId parameters.
+	StartRowId    int64  `json:"startRowId,omitempty"`    // Defines beginning edge of range when specifying one or more rows. To specify an entire row, omit the startColumnId and endColumnId parameters.
+	Name          string `json:"name,omitempty"`          // Friendly name of reference. Auto-generated unless specified in Create Cross-sheet References.
+	Status        string `json:"status,omitempty"`        // Status of the reference, for instance OK or BLOCKED. Read only.
+}
+
+// Return the created CrossSheetReference object. The referenced range is read from ref.
+func (c Client) CreateCrossSheetReference(sheetId int64, ref CrossSheetReference) (*CrossSheetReference, error) {
+	var created CrossSheetReference
+	res := ResultObject{Result: &created}
+	ref.Id, ref.Status = 0, ""
+	resp, err := c.post(fmt.Sprintf("%s/sheets/%d/crosssheetreferences", apiEndpoint, sheetId), ref, nil)
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &created, nil
 }
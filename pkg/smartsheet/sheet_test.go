@@ -19,18 +19,17 @@ package smartsheet
 import (
 	"reflect"
 	"testing"
-	"time"
 )
 
 func TestSheet_GetColumnById(t *testing.T) {
 	type fields struct {
-		Id                         int
-		FromId                     int
-		OwnerId                    int
-		AccessLevel                string
+		Id                         int64
+		FromId                     int64
+		OwnerId                    int64
+		AccessLevel                AccessLevel
 		Attachments                []Attachment
 		Columns                    []Column
-		CreatedAt                  time.Time
+		CreatedAt                  string
 		CrossSheetReferences       []CrossSheetReference
 		DependenciesEnabled        bool
 		Discussions                []Discussion
@@ -38,7 +37,7 @@ func TestSheet_GetColumnById(t *testing.T) {
 		Favorite                   bool
 		GanttEnabled               bool
 		HasSummaryFields           bool
-		ModifiedAt                 time.Time
+		ModifiedAt                 string
 		Name                       string
 		Owner                      string
 		Permalink                  string
@@ -56,7 +55,7 @@ func TestSheet_GetColumnById(t *testing.T) {
 		Workspace                  Workspace
 	}
 	type args struct {
-		id int
+		id int64
 	}
 	tests := []struct {
 		name    string
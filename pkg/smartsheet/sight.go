@@ -0,0 +1,177 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type Sight struct {
+	Id              int64           `json:"id"`                  // Sight Id
+	AccessLevel     AccessLevel     `json:"accessLevel"`         // User's permissions on the Sight
+	BackgroundColor string          `json:"backgroundColor"`     // The hex color, for instance #E6F5FE
+	ColumnCount     int             `json:"columnCount"`         //	Number of columns that the Sight contains
+	CreatedAt       time.Time       `json:"createdAt"`           //	Time of creation
+	Favorite        bool            `json:"favorite"`            //	Indicates whether the user has marked the Sight as a favorite
+	ModifiedAt      time.Time       `json:"modifiedAt"`          //	Time of last modification
+	Name            string          `json:"name"`                //	Sight name
+	Permalink       string          `json:"permalink"`           //	URL that represents a direct link to the Sight in Smartsheet
+	Source          Source          `json:"source"`              //	A Source object indicating the Sight (aka dashboard) from which this Sight was created, if any
+	Widgets         []Widget        `json:"widgets"`             //	Array of Widget objects
+	Workspace       *SightWorkspace `json:"workspace,omitempty"` //	The workspace the Sight is in, if any
+}
+
+// SightWorkspace is the workspace of a Sight, which the API limits to its id and name
+type SightWorkspace struct {
+	Id   int64  `json:"id"`   // Workspace Id
+	Name string `json:"name"` // Workspace name
+}
+
+// SightPage is a page of Sights returned by ListSights
+type SightPage struct {
+	PageInfo
+	Data []Sight `json:"data"`
+}
+
+// SightUpdate holds the changes sent by UpdateSight. Empty fields are left unchanged;
+// when Widgets is set it replaces every widget on the Sight.
+type SightUpdate struct {
+	Name    string   `json:"name,omitempty"`    // New Sight name
+	Widgets []Widget `json:"widgets,omitempty"` // Widgets of the Sight
+}
+
+// SightPublish is the publish status of a Sight
+type SightPublish struct {
+	ReadOnlyFullEnabled      bool   `json:"readOnlyFullEnabled"`                // True if the read only full publish is enabled
+	ReadOnlyFullAccessibleBy string `json:"readOnlyFullAccessibleBy,omitempty"` // ALL or ORG. Who can see the published Sight
+	ReadOnlyFullUrl          string `json:"readOnlyFullUrl,omitempty"`          // URL of the published Sight. Read only
+}
+
+// Return SightPage object with the Sights the user can access
+func (c Client) ListSights(options *PageOptions) (*SightPage, error) {
+	var page SightPage
+	query := url.Values{}
+	options.setQuery(query)
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/sights", apiEndpoint), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &page); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &page, nil
+}
+
+// Return Sight object with its widgets. level sets the compatibility level of
+// widget contents, use 0 for the oldest level.
+func (c Client) GetSight(sightId int64, level int) (*Sight, error) {
+	var sight Sight
+	query := url.Values{}
+	if level > 0 {
+		query.Set("level", strconv.Itoa(level))
+	}
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/sights/%d", apiEndpoint, sightId), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &sight); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &sight, nil
+}
+
+// Return the updated Sight object
+func (c Client) UpdateSight(sightId int64, update SightUpdate) (*Sight, error) {
+	resp, err := c.put(fmt.Sprintf("%s/sights/%d", apiEndpoint, sightId), update, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeSight(resp)
+}
+
+// Return ResultObject object
+func (c Client) DeleteSight(sightId int64) (*ResultObject, error) {
+	var res ResultObject
+	resp, err := c.delete(fmt.Sprintf("%s/sights/%d", apiEndpoint, sightId))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &res, nil
+}
+
+// Return the new Sight object. Only its id, name and permalink are set.
+func (c Client) CopySight(sightId int64, destination ContainerDestination) (*Sight, error) {
+	resp, err := c.post(fmt.Sprintf("%s/sights/%d/copy", apiEndpoint, sightId), destination, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeSight(resp)
+}
+
+// Return the moved Sight object. The destination's NewName is ignored.
+func (c Client) MoveSight(sightId int64, destination ContainerDestination) (*Sight, error) {
+	destination.NewName = ""
+	resp, err := c.post(fmt.Sprintf("%s/sights/%d/move", apiEndpoint, sightId), destination, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeSight(resp)
+}
+
+// Return SightPublish object with the publish status of a Sight
+func (c Client) GetSightPublishStatus(sightId int64) (*SightPublish, error) {
+	var publish SightPublish
+	resp, err := c.get(fmt.Sprintf("%s/sights/%d/publish", apiEndpoint, sightId))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &publish); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &publish, nil
+}
+
+// Return the SightPublish object after changing the publish status of a Sight
+func (c Client) SetSightPublishStatus(sightId int64, publish SightPublish) (*SightPublish, error) {
+	publish.ReadOnlyFullUrl = ""
+	var updated SightPublish
+	resp, err := c.put(fmt.Sprintf("%s/sights/%d/publish", apiEndpoint, sightId), publish, nil)
+	if err != nil {
+		return nil, err
+	}
+	res := ResultObject{Result: &updated}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &updated, nil
+}
+
+func (c Client) decodeSight(resp *http.Response) (*Sight, error) {
+	var sight Sight
+	res := ResultObject{Result: &sight}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &sight, nil
+}
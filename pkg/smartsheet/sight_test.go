@@ -0,0 +1,121 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestClient_ListSights(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sights", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("includeAll"))
+		_, _ = w.Write([]byte(`{"pageNumber":1,"totalPages":1,"totalCount":1,"data":[{"id":7,"name":"Status","accessLevel":"OWNER","createdAt":"2020-01-02T03:04:05Z"}]}`))
+	})
+	defer done()
+	page, err := client.ListSights(&PageOptions{IncludeAll: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), page.Data[0].Id)
+	assert.Equal(t, AccessLevelOwner, page.Data[0].AccessLevel)
+	assert.Equal(t, 2020, page.Data[0].CreatedAt.Year())
+}
+
+func TestClient_GetSight(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sights/7", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("level"))
+		_, _ = w.Write([]byte(`{"id":7,"name":"Status","workspace":{"id":3,"name":"Ops"},"widgets":[{"id":11,"type":"RICHTEXT","width":4,"height":2,"xPosition":1,"contents":{"htmlContent":"<p>Hi</p>"}}]}`))
+	})
+	defer done()
+	sight, err := client.GetSight(7, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, &SightWorkspace{Id: 3, Name: "Ops"}, sight.Workspace)
+	assert.Equal(t, int64(11), sight.Widgets[0].Id)
+	assert.Equal(t, 1, sight.Widgets[0].XPosition)
+}
+
+func TestClient_UpdateDeleteSight(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sights/7", r.URL.Path)
+		switch r.Method {
+		case "PUT":
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]interface{}{"name": "Renamed"}, body)
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":7,"name":"Renamed"}}`))
+		case "DELETE":
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	defer done()
+	sight, err := client.UpdateSight(7, SightUpdate{Name: "Renamed"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed", sight.Name)
+	res, err := client.DeleteSight(7)
+	assert.NoError(t, err)
+	assert.Equal(t, "SUCCESS", res.Message)
+}
+
+func TestClient_CopyMoveSight(t *testing.T) {
+	var bodies []map[string]interface{}
+	var paths []string
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		paths = append(paths, r.URL.Path)
+		var body map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		bodies = append(bodies, body)
+		_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":8,"name":"Copy"}}`))
+	})
+	defer done()
+	destination := ContainerDestination{DestinationId: 3, DestinationType: DestinationWorkspace, NewName: "Copy"}
+	sight, err := client.CopySight(7, destination)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(8), sight.Id)
+	_, err = client.MoveSight(7, destination)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"/sights/7/copy", "/sights/7/move"}, paths)
+	assert.Equal(t, "Copy", bodies[0]["newName"])
+	assert.NotContains(t, bodies[1], "newName")
+}
+
+func TestClient_SightPublishStatus(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/sights/7/publish", r.URL.Path)
+		switch r.Method {
+		case "GET":
+			_, _ = w.Write([]byte(`{"readOnlyFullEnabled":false}`))
+		case "PUT":
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]interface{}{"readOnlyFullEnabled": true, "readOnlyFullAccessibleBy": "ORG"}, body)
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"readOnlyFullEnabled":true,"readOnlyFullAccessibleBy":"ORG","readOnlyFullUrl":"https://publish.smartsheet.com/x"}}`))
+		}
+	})
+	defer done()
+	publish, err := client.GetSightPublishStatus(7)
+	assert.NoError(t, err)
+	assert.False(t, publish.ReadOnlyFullEnabled)
+	publish, err = client.SetSightPublishStatus(7, SightPublish{ReadOnlyFullEnabled: true, ReadOnlyFullAccessibleBy: "ORG", ReadOnlyFullUrl: "ignored"})
+	assert.NoError(t, err)
+	assert.Equal(t, "https://publish.smartsheet.com/x", publish.ReadOnlyFullUrl)
+}
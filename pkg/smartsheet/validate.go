@@ -0,0 +1,172 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CellValidationError describes a cell that the API would reject
+type CellValidationError struct {
+	RowIndex int    // Position of the row in the validated rows
+	RowId    int64  // Id of the row, if it has one
+	ColumnId int64  // Id of the cell's column
+	Column   string // Title of the cell's column, if the column exists
+	Message  string // What is wrong with the cell
+}
+
+func (e CellValidationError) Error() string {
+	column := e.Column
+	if column == "" {
+		column = fmt.Sprint(e.ColumnId)
+	}
+	return fmt.Sprintf("row %d, column %s: %s", e.RowIndex, column, e.Message)
+}
+
+// ValidationErrors is every violation found by Sheet.ValidateRows
+type ValidationErrors []CellValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i := range e {
+		messages[i] = e[i].Error()
+	}
+	return fmt.Sprintf("%d invalid cells: %s", len(e), strings.Join(messages, "; "))
+}
+
+// Check rows against the sheet's column definitions before sending them. Cells are checked for
+// unknown, system and locked columns, values outside of validated picklist and contact options,
+// non-boolean checkbox values and unparseable dates. Cells with strict set to false skip the type
+// checks and cells with overrideValidation skip the option checks, as the API does.
+// The returned error, if any, is a ValidationErrors.
+func (s Sheet) ValidateRows(rows []Row) error {
+	columns := map[int64]*Column{}
+	for i := range s.Columns {
+		columns[s.Columns[i].Id] = &s.Columns[i]
+	}
+	var errs ValidationErrors
+	for i, row := range rows {
+		for _, cell := range row.Cells {
+			violation := func(format string, a ...interface{}) {
+				e := CellValidationError{RowIndex: i, RowId: row.Id, ColumnId: cell.ColumnId, Message: fmt.Sprintf(format, a...)}
+				if column, ok := columns[cell.ColumnId]; ok {
+					e.Column = column.Title
+				}
+				errs = append(errs, e)
+			}
+			column, ok := columns[cell.ColumnId]
+			if !ok {
+				violation("no such column")
+				continue
+			}
+			if column.SystemColumnType != "" {
+				violation("%s system columns cannot be written", column.SystemColumnType)
+				continue
+			}
+			if column.LockedForUser {
+				violation("column is locked")
+				continue
+			}
+			strict := cell.Strict == nil || *cell.Strict
+			if cell.OverrideValidation && strict {
+				violation("overrideValidation requires strict to be false")
+			}
+			if cell.Formula != "" || cell.LinkInFromCell != nil || (cell.Value == nil && cell.ObjectValue == nil) {
+				continue
+			}
+			if strict {
+				if msg := checkCellType(*column, cell); msg != "" {
+					violation(msg)
+				}
+			}
+			if column.Validation && !cell.OverrideValidation {
+				if msg := checkCellOptions(*column, cell); msg != "" {
+					violation(msg)
+				}
+			}
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// Return a description of why the cell's value does not match the column type, or an empty string
+func checkCellType(column Column, cell Cell) string {
+	switch {
+	case column.Type == ColumnTypeCheckbox:
+		if _, ok := cell.Value.(bool); !ok && cell.Value != nil {
+			return fmt.Sprintf("checkbox value must be a boolean, not %T", cell.Value)
+		}
+	case column.Type == ColumnTypeDate || column.Type == ColumnTypeAbstractDateTime:
+		cell.ColumnType = column.Type
+		if _, err := cell.Time(); err != nil {
+			return fmt.Sprintf("invalid date: %v", err)
+		}
+	}
+	return ""
+}
+
+// Return a description of why the cell's value is not one of the column's options, or an empty string
+func checkCellOptions(column Column, cell Cell) string {
+	switch column.Type {
+	case ColumnTypePicklist, ColumnTypeMultiPicklist:
+		if len(column.Options) == 0 {
+			return ""
+		}
+		cell.ColumnType = column.Type
+		values, err := cell.Strings()
+		if err != nil {
+			values = []string{fmt.Sprint(cell.Value)}
+		}
+		for _, value := range values {
+			if !containsString(column.Options, value) {
+				return fmt.Sprintf("%q is not one of the column options", value)
+			}
+		}
+	case ColumnTypeContactList, ColumnTypeMultiContactList:
+		if len(column.ContactOptions) == 0 {
+			return ""
+		}
+		cell.ColumnType = column.Type
+		contacts, err := cell.Contacts()
+		if err != nil {
+			return err.Error()
+		}
+		for _, contact := range contacts {
+			found := false
+			for _, option := range column.ContactOptions {
+				found = found || strings.EqualFold(option.Email, contact.Email)
+			}
+			if !found {
+				return fmt.Sprintf("%s is not one of the column contact options", contact.Email)
+			}
+		}
+	}
+	return ""
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSheet_ValidateRows(t *testing.T) {
+	lenient := false
+	sheet := Sheet{Columns: []Column{
+		{Id: 1, Title: "Status", Type: ColumnTypePicklist, Options: []string{"Open", "Closed"}, Validation: true},
+		{Id: 2, Title: "Owner", Type: ColumnTypeContactList, ContactOptions: []ContactOption{{Email: "a@example.com"}}, Validation: true},
+		{Id: 3, Title: "Done", Type: ColumnTypeCheckbox},
+		{Id: 4, Title: "Due", Type: ColumnTypeDate},
+		{Id: 5, Title: "Created", Type: ColumnTypeDateTime, SystemColumnType: SystemColumnCreatedDate},
+		{Id: 6, Title: "Budget", Type: ColumnTypeTextNumber, LockedForUser: true},
+		{Id: 7, Title: "Tags", Type: ColumnTypeMultiPicklist, Options: []string{"a", "b"}, Validation: true},
+	}}
+	tests := []struct {
+		name  string
+		cell  Cell
+		valid bool
+	}{
+		{"picklist option", Cell{ColumnId: 1, Value: "Open"}, true},
+		{"picklist other", Cell{ColumnId: 1, Value: "Blocked"}, false},
+		{"picklist override", Cell{ColumnId: 1, Value: "Blocked", OverrideValidation: true, Strict: &lenient}, true},
+		{"override needs lenient", Cell{ColumnId: 1, Value: "Open", OverrideValidation: true}, false},
+		{"contact option", Cell{ColumnId: 2, Value: "A@example.com"}, true},
+		{"contact other", NewContactCell(2, Contact{Email: "b@example.com"}), false},
+		{"checkbox", Cell{ColumnId: 3, Value: true}, true},
+		{"checkbox text", Cell{ColumnId: 3, Value: "yes"}, false},
+		{"checkbox lenient", Cell{ColumnId: 3, Value: "yes", Strict: &lenient}, true},
+		{"date", Cell{ColumnId: 4, Value: "2026-10-19"}, true},
+		{"date text", Cell{ColumnId: 4, Value: "next week"}, false},
+		{"formula", Cell{ColumnId: 4, Formula: "=TODAY()"}, true},
+		{"system column", Cell{ColumnId: 5, Value: "2026-10-19"}, false},
+		{"locked column", Cell{ColumnId: 6, Value: 1.0}, false},
+		{"unknown column", Cell{ColumnId: 99, Value: 1.0}, false},
+		{"multi picklist", NewMultiPicklistCell(7, "a", "b"), true},
+		{"multi picklist other", NewMultiPicklistCell(7, "a", "c"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := sheet.ValidateRows([]Row{{Id: 10, Cells: []Cell{tt.cell}}})
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+			assert.Error(t, err)
+			errs := err.(ValidationErrors)
+			assert.Equal(t, int64(10), errs[0].RowId)
+			assert.Equal(t, tt.cell.ColumnId, errs[0].ColumnId)
+		})
+	}
+}
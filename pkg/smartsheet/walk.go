@@ -0,0 +1,258 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"errors"
+	"strings"
+	"sync"
+)
+
+// SkipFolder can be returned by a WalkFunc visiting a folder or workspace to skip its contents
+var SkipFolder = errors.New("skip this folder")
+
+// WalkItem is a sheet, report, Sight, template, folder or workspace found by a Walker.
+// Only the field matching Type is set, with the attributes returned in its parent's listing.
+type WalkItem struct {
+	Type      ItemType   // Kind of the item
+	Id        int64      // Id of the item
+	Name      string     // Name of the item
+	Path      string     // Logical path of the item, for instance Engineering/2026/Roadmap. Slashes in names are escaped as \/
+	Sheet     *Sheet     // Set for sheets
+	Report    *Report    // Set for reports
+	Sight     *Sight     // Set for Sights
+	Template  *Template  // Set for templates
+	Folder    *Folder    // Set for folders
+	Workspace *Workspace // Set for workspaces
+}
+
+// WalkFunc is called for every item found by a Walker. Returning SkipFolder for a folder or workspace
+// skips its contents; returning any other error stops the walk.
+type WalkFunc func(item WalkItem) error
+
+// Walker traverses Home, workspaces and folders. Folder and workspace contents are fetched only
+// when the walk descends into them, with up to Concurrency requests at a time. The WalkFunc is
+// never called concurrently, but items are visited in no particular order.
+type Walker struct {
+	Client      Client
+	Concurrency int // Maximum number of concurrent requests. Defaults to 4
+}
+
+type walk struct {
+	client Client
+	fn     WalkFunc
+	sem    chan struct{}
+	wg     sync.WaitGroup
+	mu     sync.Mutex // serializes fn and guards err
+	err    error
+}
+
+// Return Walker with default concurrency
+func (c Client) NewWalker() *Walker {
+	return &Walker{Client: c, Concurrency: 4}
+}
+
+// Visit every item in Home, including the contents of workspaces. Paths of workspace items start
+// with the workspace name.
+func (w *Walker) WalkHome(fn WalkFunc) error {
+	return w.run(fn, func(wk *walk) {
+		wk.fetch(func() error {
+			home, err := wk.client.GetHome(nil)
+			if err != nil {
+				return err
+			}
+			wk.contents("", home.Sheets, home.Reports, home.Sights, home.Templates, home.Folders)
+			for i := range home.Workspaces {
+				wk.workspace(&home.Workspaces[i], false)
+			}
+			return nil
+		})
+	})
+}
+
+// Visit the workspace and every item in it
+func (w *Walker) WalkWorkspace(workspaceId int64, fn WalkFunc) error {
+	return w.run(fn, func(wk *walk) {
+		wk.fetch(func() error {
+			workspace, err := wk.client.GetWorkspace(workspaceId, nil)
+			if err != nil {
+				return err
+			}
+			wk.workspace(workspace, true)
+			return nil
+		})
+	})
+}
+
+// Visit the folder and every item in it
+func (w *Walker) WalkFolder(folderId int64, fn WalkFunc) error {
+	return w.run(fn, func(wk *walk) {
+		wk.fetch(func() error {
+			folder, err := wk.client.GetFolder(folderId, nil)
+			if err != nil {
+				return err
+			}
+			path := joinPath("", folder.Name)
+			if wk.visit(WalkItem{Type: ItemFolder, Id: folder.Id, Name: folder.Name, Path: path, Folder: folder}) {
+				wk.contents(path, folder.Sheets, folder.Reports, folder.Sights, folder.Templates, folder.Folders)
+			}
+			return nil
+		})
+	})
+}
+
+func (w *Walker) run(fn WalkFunc, start func(*walk)) error {
+	concurrency := w.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	wk := &walk{client: w.Client, fn: fn, sem: make(chan struct{}, concurrency)}
+	start(wk)
+	wk.wg.Wait()
+	return wk.err
+}
+
+// Run a request in the background, holding a slot of the concurrency bound while it runs
+func (wk *walk) fetch(request func() error) {
+	wk.wg.Add(1)
+	go func() {
+		defer wk.wg.Done()
+		if wk.stopped() {
+			return
+		}
+		wk.sem <- struct{}{}
+		err := request()
+		<-wk.sem
+		if err != nil {
+			wk.mu.Lock()
+			if wk.err == nil {
+				wk.err = err
+			}
+			wk.mu.Unlock()
+		}
+	}()
+}
+
+// Visit a workspace, then its contents unless skipped. Contents are fetched first if not loaded.
+func (wk *walk) workspace(workspace *Workspace, loaded bool) {
+	path := joinPath("", workspace.Name)
+	if !wk.visit(WalkItem{Type: ItemWorkspace, Id: workspace.Id, Name: workspace.Name, Path: path, Workspace: workspace}) {
+		return
+	}
+	if loaded {
+		wk.contents(path, workspace.Sheets, workspace.Reports, workspace.Sights, workspace.Templates, workspace.Folders)
+		return
+	}
+	id := workspace.Id
+	wk.fetch(func() error {
+		loaded, err := wk.client.GetWorkspace(id, nil)
+		if err != nil {
+			return err
+		}
+		wk.contents(path, loaded.Sheets, loaded.Reports, loaded.Sights, loaded.Templates, loaded.Folders)
+		return nil
+	})
+}
+
+// Visit the items of a container. Subfolders are fetched unless skipped.
+func (wk *walk) contents(path string, sheets []Sheet, reports []Report, sights []Sight, templates []Template, folders []Folder) {
+	for _, item := range containerItems(path, sheets, reports, sights, templates, folders) {
+		if !wk.visit(item) || item.Type != ItemFolder {
+			continue
+		}
+		id, folderPath := item.Id, item.Path
+		wk.fetch(func() error {
+			loaded, err := wk.client.GetFolder(id, nil)
+			if err != nil {
+				return err
+			}
+			wk.contents(folderPath, loaded.Sheets, loaded.Reports, loaded.Sights, loaded.Templates, loaded.Folders)
+			return nil
+		})
+	}
+}
+
+// Call the WalkFunc. Return true if the walk should descend into the item.
+func (wk *walk) visit(item WalkItem) bool {
+	wk.mu.Lock()
+	defer wk.mu.Unlock()
+	if wk.err != nil {
+		return false
+	}
+	if err := wk.fn(item); err != nil {
+		if err != SkipFolder {
+			wk.err = err
+		}
+		return false
+	}
+	return true
+}
+
+func (wk *walk) stopped() bool {
+	wk.mu.Lock()
+	defer wk.mu.Unlock()
+	return wk.err != nil
+}
+
+// Return the items of a container, with paths under the container's path
+func containerItems(path string, sheets []Sheet, reports []Report, sights []Sight, templates []Template, folders []Folder) []WalkItem {
+	items := make([]WalkItem, 0, len(sheets)+len(reports)+len(sights)+len(templates)+len(folders))
+	for i := range sheets {
+		items = append(items, WalkItem{Type: ItemSheet, Id: sheets[i].Id, Name: sheets[i].Name, Path: joinPath(path, sheets[i].Name), Sheet: &sheets[i]})
+	}
+	for i := range reports {
+		items = append(items, WalkItem{Type: ItemReport, Id: reports[i].Id, Name: reports[i].Name, Path: joinPath(path, reports[i].Name), Report: &reports[i]})
+	}
+	for i := range sights {
+		items = append(items, WalkItem{Type: ItemSight, Id: sights[i].Id, Name: sights[i].Name, Path: joinPath(path, sights[i].Name), Sight: &sights[i]})
+	}
+	for i := range templates {
+		items = append(items, WalkItem{Type: ItemTemplate, Id: templates[i].Id, Name: templates[i].Name, Path: joinPath(path, templates[i].Name), Template: &templates[i]})
+	}
+	for i := range folders {
+		items = append(items, WalkItem{Type: ItemFolder, Id: folders[i].Id, Name: folders[i].Name, Path: joinPath(path, folders[i].Name), Folder: &folders[i]})
+	}
+	return items
+}
+
+// Append a name to a path. Slashes and backslashes in the name are escaped with a backslash.
+func joinPath(parent string, name string) string {
+	name = strings.NewReplacer(`\`, `\\`, "/", `\/`).Replace(name)
+	if parent == "" {
+		return name
+	}
+	return parent + "/" + name
+}
+
+// Split a path into unescaped names
+func splitPath(path string) []string {
+	var names []string
+	var name strings.Builder
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '\\' && i+1 < len(path):
+			i++
+			name.WriteByte(path[i])
+		case path[i] == '/':
+			names = append(names, name.String())
+			name.Reset()
+		default:
+			name.WriteByte(path[i])
+		}
+	}
+	return append(names, name.String())
+}
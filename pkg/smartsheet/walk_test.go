@@ -0,0 +1,97 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"sort"
+	"sync/atomic"
+	"testing"
+)
+
+func TestWalker_WalkHome(t *testing.T) {
+	var requests int32
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		switch r.URL.Path {
+		case "/home":
+			_, _ = w.Write([]byte(`{"sheets":[{"id":1,"name":"Inbox"}],"folders":[{"id":10,"name":"Personal"}],"workspaces":[{"id":20,"name":"Engineering"}]}`))
+		case "/folders/10":
+			_, _ = w.Write([]byte(`{"id":10,"name":"Personal","templates":[{"id":2,"name":"Checklist"}]}`))
+		case "/workspaces/20":
+			_, _ = w.Write([]byte(`{"id":20,"name":"Engineering","folders":[{"id":30,"name":"2026"},{"id":31,"name":"Archive"}],"sights":[{"id":3,"name":"Status"}]}`))
+		case "/folders/30":
+			_, _ = w.Write([]byte(`{"id":30,"name":"2026","sheets":[{"id":4,"name":"Roadmap"}],"reports":[{"id":5,"name":"Open items"}]}`))
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+		}
+	})
+	defer done()
+	var paths []string
+	walker := client.NewWalker()
+	walker.Concurrency = 2
+	err := walker.WalkHome(func(item WalkItem) error {
+		paths = append(paths, string(item.Type)+" "+item.Path)
+		if item.Type == ItemFolder && item.Name == "Archive" {
+			return SkipFolder
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	sort.Strings(paths)
+	assert.Equal(t, []string{
+		"folder Engineering/2026",
+		"folder Engineering/Archive",
+		"folder Personal",
+		"report Engineering/2026/Open items",
+		"sheet Engineering/2026/Roadmap",
+		"sheet Inbox",
+		"sight Engineering/Status",
+		"template Personal/Checklist",
+		"workspace Engineering",
+	}, paths)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+}
+
+func TestWalker_WalkFolder(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/folders/1":
+			_, _ = w.Write([]byte(`{"id":1,"name":"Plans","sheets":[{"id":2,"name":"A"}],"folders":[{"id":3,"name":"Old"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"errorCode":1006,"message":"Not Found"}`))
+		}
+	})
+	defer done()
+	var items []WalkItem
+	err := client.NewWalker().WalkFolder(1, func(item WalkItem) error {
+		items = append(items, item)
+		return nil
+	})
+	assert.Error(t, err)
+	assert.Equal(t, "Plans", items[0].Path)
+	assert.Equal(t, int64(1), items[0].Folder.Id)
+
+	stop := errors.New("stop")
+	err = client.NewWalker().WalkFolder(1, func(item WalkItem) error {
+		return stop
+	})
+	assert.Equal(t, stop, err)
+}
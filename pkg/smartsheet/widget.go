@@ -0,0 +1,367 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+)
+
+// Widget is a Sight widget. Contents is decoded by Type into one of the content types below:
+//
+//	CHART                                  *ChartContent
+//	GRIDGANTT                              *ReportContent
+//	IMAGE                                  *ImageContent
+//	METRIC, SHEETSUMMARY                   *MetricContent
+//	RICHTEXT                               *RichTextContent
+//	SHORTCUT, SHORTCUTICON, SHORTCUTLIST   *ShortcutContent
+//	TITLE                                  *TitleContent
+//	WEBCONTENT                             *WebContent
+//
+// Contents of other types are kept as json.RawMessage. A widget decoded from the API keeps its
+// JSON, and is encoded as that JSON with only the changed attributes replaced. Attributes the
+// types don't model and zero values sent by the API are kept, so widgets can be sent back
+// without losing data.
+type Widget struct {
+	Id            int64       `json:"id,omitempty"`  // 	Widget Id
+	Type          WidgetType  `json:"type"`          // Type of widget
+	Contents      interface{} `json:"contents"`      // Data that specifies the contents of the widget. See above for the type for each widget type.
+	Height        int         `json:"height"`        // 	Number of rows that the widget occupies on the Sight
+	ShowTitle     bool        `json:"showTitle"`     //	True indicates that the client should display the widget title. NOTE: This is independent of the title string which may be null or empty.
+	ShowTitleIcon bool        `json:"showTitleIcon"` //	True indicates that the client should display the sheet icon in the widget title
+	Title         string      `json:"title"`         // Title of the widget
+	TitleFormat   string      `json:"titleFormat"`   // FormatDescriptor
+	Version       int         `json:"version"`       // Widget version int //
+	ViewMode      int         `json:"viewMode"`      //	1 indicates content is centered. 2 indicates content is left aligned. Must use a query parameter of level=2 to see this information.
+	Width         int         `json:"width"`         // 	Number of columns that the widget occupies on the Sight
+	XPosition     int         `json:"xPosition"`     // 	X-coordinate of widget's position on the Sight
+	YPosition     int         `json:"yPosition"`     // 	Y-coordinate of widget's position on the Sight
+	raw           json.RawMessage
+}
+
+// WidgetHyperlink is where a widget or shortcut leads when clicked
+type WidgetHyperlink struct {
+	InteractionType string `json:"interactionType,omitempty"` // DISTINCT_OBJECT, SMARTSHEET_ITEM, WEB or NONE
+	FolderId        int64  `json:"folderId,omitempty"`        // Id of the linked folder
+	ReportId        int64  `json:"reportId,omitempty"`        // Id of the linked report
+	SheetId         int64  `json:"sheetId,omitempty"`         // Id of the linked sheet
+	SightId         int64  `json:"sightId,omitempty"`         // Id of the linked Sight
+	WorkspaceId     int64  `json:"workspaceId,omitempty"`     // Id of the linked workspace
+	Url             string `json:"url,omitempty"`             // The linked URL
+}
+
+// RichTextContent is the contents of a RICHTEXT widget
+type RichTextContent struct {
+	HtmlContent string `json:"htmlContent"` // The widget's HTML
+}
+
+// TitleContent is the contents of a TITLE widget
+type TitleContent struct {
+	BackgroundColor string `json:"backgroundColor,omitempty"` // The hex color, for instance #E6F5FE
+	HtmlContent     string `json:"htmlContent"`               // The title's HTML
+}
+
+// ShortcutContent is the contents of a SHORTCUT, SHORTCUTICON or SHORTCUTLIST widget
+type ShortcutContent struct {
+	ShortcutData []ShortcutItem `json:"shortcutData"` // The shortcuts, one for SHORTCUT and SHORTCUTICON widgets
+}
+
+type ShortcutItem struct {
+	AttachmentType string           `json:"attachmentType,omitempty"` // Type of the linked attachment, if any
+	Hyperlink      *WidgetHyperlink `json:"hyperlink,omitempty"`      // Where the shortcut leads
+	Label          string           `json:"label"`                    // Label of the shortcut
+	LabelFormat    string           `json:"labelFormat,omitempty"`    // FormatDescriptor of the label
+	MimeType       string           `json:"mimeType,omitempty"`       // MIME type of the linked attachment, if any
+	Order          int              `json:"order"`                    // Position of the shortcut in the list
+}
+
+// MetricContent is the contents of a METRIC or SHEETSUMMARY widget, showing cells or summary fields of a sheet
+type MetricContent struct {
+	SheetId   int64            `json:"sheetId,omitempty"`   // Id of the sheet the data comes from
+	CellData  []CellDataItem   `json:"cellData"`            // The values shown
+	Columns   []Column         `json:"columns,omitempty"`   // Columns of the cells shown
+	Hyperlink *WidgetHyperlink `json:"hyperlink,omitempty"` // Where the widget leads when clicked
+}
+
+// CellDataItem is a value shown by a METRIC or SHEETSUMMARY widget
+type CellDataItem struct {
+	ColumnId    int64        `json:"columnId,omitempty"`    // Id of the column of the cell
+	RowId       int64        `json:"rowId,omitempty"`       // Id of the row of the cell
+	Cell        *Cell        `json:"cell,omitempty"`        // The cell shown
+	ObjectValue *ObjectValue `json:"objectValue,omitempty"` // The value shown, for summary fields
+	Label       string       `json:"label,omitempty"`       // Label of the value
+	LabelFormat string       `json:"labelFormat,omitempty"` // FormatDescriptor of the label
+	ValueFormat string       `json:"valueFormat,omitempty"` // FormatDescriptor of the value
+	Order       int          `json:"order"`                 // Position of the value in the widget
+}
+
+// ChartContent is the contents of a CHART widget
+type ChartContent struct {
+	ReportId          int64            `json:"reportId,omitempty"`          // Id of the report the data comes from
+	SheetId           int64            `json:"sheetId,omitempty"`           // Id of the sheet the data comes from
+	Axes              []ChartAxis      `json:"axes,omitempty"`              // Axes of the chart
+	Series            []ChartSeries    `json:"series,omitempty"`            // Series plotted on the chart
+	Legend            *ChartLegend     `json:"legend,omitempty"`            // Legend of the chart
+	Hyperlink         *WidgetHyperlink `json:"hyperlink,omitempty"`         // Where the widget leads when clicked
+	IncludedColumnIds []int64          `json:"includedColumnIds,omitempty"` // Columns the data comes from
+	SelectionRanges   []SelectionRange `json:"selectionRanges,omitempty"`   // Cell ranges the data comes from
+}
+
+type ChartAxis struct {
+	Location string `json:"location,omitempty"` // Where the axis is drawn, for instance BOTTOM or LEFT
+	Title    string `json:"title,omitempty"`    // Title of the axis
+}
+
+type ChartSeries struct {
+	Title      string `json:"title,omitempty"`      // Title of the series
+	SeriesType string `json:"seriesType,omitempty"` // How the series is drawn, for instance BAR or LINE
+}
+
+type ChartLegend struct {
+	Location string `json:"location,omitempty"` // Where the legend is drawn, for instance RIGHT
+}
+
+// SelectionRange is a rectangle of cells, given by its corner rows and columns
+type SelectionRange struct {
+	SourceColumnId1 int64 `json:"sourceColumnId1,omitempty"`
+	SourceColumnId2 int64 `json:"sourceColumnId2,omitempty"`
+	SourceRowId1    int64 `json:"sourceRowId1,omitempty"`
+	SourceRowId2    int64 `json:"sourceRowId2,omitempty"`
+}
+
+// ReportContent is the contents of a GRIDGANTT widget, showing a report
+type ReportContent struct {
+	ReportId    int64            `json:"reportId,omitempty"`    // Id of the report shown
+	HtmlContent string           `json:"htmlContent,omitempty"` // HTML of the report's contents. Read only
+	Hyperlink   *WidgetHyperlink `json:"hyperlink,omitempty"`   // Where the widget leads when clicked
+}
+
+// ImageContent is the contents of an IMAGE widget
+type ImageContent struct {
+	PrivateId string           `json:"privateId,omitempty"` // Id of the image, used with GetImageUrls
+	FileName  string           `json:"fileName,omitempty"`  // Name of the image file
+	Format    string           `json:"format,omitempty"`    // FormatDescriptor
+	Height    int              `json:"height,omitempty"`    // Original height of the image in pixels
+	Width     int              `json:"width,omitempty"`     // Original width of the image in pixels
+	Hyperlink *WidgetHyperlink `json:"hyperlink,omitempty"` // Where the widget leads when clicked
+}
+
+// WebContent is the contents of a WEBCONTENT widget
+type WebContent struct {
+	Url string `json:"url"` // URL of the embedded page
+}
+
+// Decode contents by widget type. Contents of unknown types are kept as json.RawMessage.
+func (w *Widget) UnmarshalJSON(data []byte) error {
+	type widget Widget
+	var v struct {
+		widget
+		Contents json.RawMessage `json:"contents"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*w = Widget(v.widget)
+	w.raw = append(json.RawMessage(nil), data...)
+	if len(v.Contents) == 0 || bytes.Equal(v.Contents, []byte("null")) {
+		return nil
+	}
+	var contents interface{}
+	switch w.Type {
+	case WidgetChart:
+		contents = &ChartContent{}
+	case WidgetGridGantt:
+		contents = &ReportContent{}
+	case WidgetImage:
+		contents = &ImageContent{}
+	case WidgetMetric, WidgetSheetSummary:
+		contents = &MetricContent{}
+	case WidgetRichText:
+		contents = &RichTextContent{}
+	case WidgetShortcut, WidgetShortcutIcon, WidgetShortcutList:
+		contents = &ShortcutContent{}
+	case WidgetTitle:
+		contents = &TitleContent{}
+	case WidgetWebContent:
+		contents = &WebContent{}
+	default:
+		w.Contents = append(json.RawMessage(nil), v.Contents...)
+		return nil
+	}
+	if err := json.Unmarshal(v.Contents, contents); err != nil {
+		return err
+	}
+	w.Contents = contents
+	return nil
+}
+
+// Encode the widget. A decoded widget is encoded as its original JSON with the attributes that
+// changed since replaced.
+func (w Widget) MarshalJSON() ([]byte, error) {
+	type widget Widget
+	current, err := json.Marshal(widget(w))
+	if err != nil || w.raw == nil {
+		return current, err
+	}
+	var original Widget
+	if err := original.UnmarshalJSON(w.raw); err != nil {
+		return nil, err
+	}
+	base, err := json.Marshal(widget(original))
+	if err != nil {
+		return nil, err
+	}
+	return mergeJSON(w.raw, base, current), nil
+}
+
+// Return raw with the changes from base to current applied. base is raw as encoded by this
+// package's types, so attributes of raw missing from base are unknown to the types and kept.
+// Unchanged values keep the bytes of raw, and objects keep the order of their attributes.
+func mergeJSON(raw, base, current json.RawMessage) json.RawMessage {
+	if equalJSON(base, current) {
+		return raw
+	}
+	rawKeys, rawFields, rawOk := jsonObject(raw)
+	_, baseFields, baseOk := jsonObject(base)
+	currentKeys, currentFields, currentOk := jsonObject(current)
+	if rawOk && baseOk && currentOk {
+		var buf bytes.Buffer
+		buf.WriteByte('{')
+		write := func(key string, value json.RawMessage) {
+			if buf.Len() > 1 {
+				buf.WriteByte(',')
+			}
+			name, _ := json.Marshal(key)
+			buf.Write(name)
+			buf.WriteByte(':')
+			buf.Write(value)
+		}
+		for _, key := range rawKeys {
+			value, inCurrent := currentFields[key]
+			baseValue, inBase := baseFields[key]
+			switch {
+			case !inBase:
+				write(key, rawFields[key])
+			case inCurrent:
+				write(key, mergeJSON(rawFields[key], baseValue, value))
+			}
+		}
+		// Attributes the types add to raw, such as zero values, are only sent once changed
+		for _, key := range currentKeys {
+			if _, ok := rawFields[key]; ok {
+				continue
+			}
+			if baseValue, ok := baseFields[key]; !ok || !equalJSON(baseValue, currentFields[key]) {
+				write(key, currentFields[key])
+			}
+		}
+		buf.WriteByte('}')
+		return buf.Bytes()
+	}
+	var rawItems, baseItems, currentItems []json.RawMessage
+	if isJSONArray(raw) && isJSONArray(base) && isJSONArray(current) &&
+		json.Unmarshal(raw, &rawItems) == nil && json.Unmarshal(base, &baseItems) == nil &&
+		json.Unmarshal(current, &currentItems) == nil && len(rawItems) == len(baseItems) &&
+		sameItems(baseItems, currentItems) {
+		// Items are merged by position, so only arrays whose items stayed in place are merged
+		items := make([]json.RawMessage, len(currentItems))
+		for i := range currentItems {
+			items[i] = mergeJSON(rawItems[i], baseItems[i], currentItems[i])
+		}
+		merged, _ := json.Marshal(items)
+		return merged
+	}
+	return current
+}
+
+// Attributes identifying an item of a widget array
+var identityKeys = []string{"id", "columnId", "rowId", "sheetId", "reportId", "hyperlink"}
+
+// Return true if current holds the items of base in the same positions. An item is moved when
+// its identifying attributes changed, or when it is unchanged but was at another position.
+func sameItems(base, current []json.RawMessage) bool {
+	if len(base) != len(current) {
+		return false
+	}
+	for i := range current {
+		if equalJSON(base[i], current[i]) {
+			continue
+		}
+		for j := range base {
+			if j != i && equalJSON(base[j], current[i]) {
+				return false
+			}
+		}
+		_, baseFields, baseOk := jsonObject(base[i])
+		_, currentFields, currentOk := jsonObject(current[i])
+		if !baseOk || !currentOk {
+			continue
+		}
+		for _, key := range identityKeys {
+			baseValue, inBase := baseFields[key]
+			currentValue, inCurrent := currentFields[key]
+			if inBase != inCurrent || (inBase && !equalJSON(baseValue, currentValue)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Return the keys of a JSON object in order, and its values. ok is false if data is not an object.
+func jsonObject(data json.RawMessage) (keys []string, fields map[string]json.RawMessage, ok bool) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if token, err := dec.Token(); err != nil || token != json.Delim('{') {
+		return nil, nil, false
+	}
+	fields = map[string]json.RawMessage{}
+	for dec.More() {
+		token, err := dec.Token()
+		if err != nil {
+			return nil, nil, false
+		}
+		key := token.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, false
+		}
+		if _, seen := fields[key]; !seen {
+			keys = append(keys, key)
+		}
+		fields[key] = value
+	}
+	return keys, fields, true
+}
+
+func isJSONArray(data json.RawMessage) bool {
+	data = bytes.TrimSpace(data)
+	return len(data) > 0 && data[0] == '['
+}
+
+// Return true if a and b hold the same JSON value. Numbers are compared exactly, as ids don't fit in a float64.
+func equalJSON(a, b json.RawMessage) bool {
+	var va, vb interface{}
+	da := json.NewDecoder(bytes.NewReader(a))
+	da.UseNumber()
+	db := json.NewDecoder(bytes.NewReader(b))
+	db.UseNumber()
+	if da.Decode(&va) != nil || db.Decode(&vb) != nil {
+		return false
+	}
+	return reflect.DeepEqual(va, vb)
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestWidget_UnmarshalJSON(t *testing.T) {
+	data := `[
+		{"type":"RICHTEXT","contents":{"htmlContent":"<p>Hi</p>"}},
+		{"type":"TITLE","contents":{"htmlContent":"Status","backgroundColor":"#E6F5FE"}},
+		{"type":"SHORTCUTLIST","contents":{"shortcutData":[{"label":"Plan","order":0,"hyperlink":{"interactionType":"SMARTSHEET_ITEM","sheetId":3}}]}},
+		{"type":"METRIC","contents":{"sheetId":3,"cellData":[{"columnId":4,"rowId":5,"label":"Open","order":1,"cell":{"columnId":4,"value":12}}]}},
+		{"type":"SHEETSUMMARY","contents":{"sheetId":3,"cellData":[{"label":"Owner","order":0,"objectValue":{"objectType":"CONTACT","email":"a@b.c"}}]}},
+		{"type":"CHART","contents":{"sheetId":3,"axes":[{"location":"LEFT","title":"Count"}],"series":[{"title":"Open","seriesType":"BAR"}],"legend":{"location":"RIGHT"},"selectionRanges":[{"sourceColumnId1":4,"sourceRowId1":5}]}},
+		{"type":"GRIDGANTT","contents":{"reportId":6}},
+		{"type":"IMAGE","contents":{"privateId":"abc","fileName":"logo.png","height":10,"width":20}},
+		{"type":"WEBCONTENT","contents":{"url":"https://example.com"}},
+		{"type":"FUTURE","contents":{"anything":[1,2]}},
+		{"type":"RICHTEXT"}
+	]`
+	var widgets []Widget
+	assert.NoError(t, json.Unmarshal([]byte(data), &widgets))
+	assert.Equal(t, "<p>Hi</p>", widgets[0].Contents.(*RichTextContent).HtmlContent)
+	assert.Equal(t, "#E6F5FE", widgets[1].Contents.(*TitleContent).BackgroundColor)
+	assert.Equal(t, int64(3), widgets[2].Contents.(*ShortcutContent).ShortcutData[0].Hyperlink.SheetId)
+	metric := widgets[3].Contents.(*MetricContent)
+	assert.Equal(t, int64(5), metric.CellData[0].RowId)
+	assert.Equal(t, float64(12), metric.CellData[0].Cell.Value)
+	assert.Equal(t, "a@b.c", widgets[4].Contents.(*MetricContent).CellData[0].ObjectValue.Contact.Email)
+	chart := widgets[5].Contents.(*ChartContent)
+	assert.Equal(t, "Count", chart.Axes[0].Title)
+	assert.Equal(t, "BAR", chart.Series[0].SeriesType)
+	assert.Equal(t, "RIGHT", chart.Legend.Location)
+	assert.Equal(t, int64(4), chart.SelectionRanges[0].SourceColumnId1)
+	assert.Equal(t, int64(6), widgets[6].Contents.(*ReportContent).ReportId)
+	assert.Equal(t, 20, widgets[7].Contents.(*ImageContent).Width)
+	assert.Equal(t, "https://example.com", widgets[8].Contents.(*WebContent).Url)
+	assert.JSONEq(t, `{"anything":[1,2]}`, string(widgets[9].Contents.(json.RawMessage)))
+	assert.Nil(t, widgets[10].Contents)
+}
+
+func TestWidget_RoundTrip(t *testing.T) {
+	// One widget of each type, with attributes the types don't model, zero values and nested cells and columns
+	for _, data := range []string{
+		`{"id":1,"type":"RICHTEXT","contents":{"htmlContent":"<p>Hi & bye</p>","newFlag":false},"height":2,"showTitle":false,"showTitleIcon":false,"title":"","titleFormat":"","version":1,"viewMode":0,"width":4,"xPosition":0,"yPosition":0}`,
+		`{"type":"TITLE","id":2, "contents": {"htmlContent":"Status","backgroundColor":"#E6F5FE","align":"CENTER"},"height":1,"width":12,"xPosition":0,"yPosition":0,"newAttribute":{"a":[1,2]}}`,
+		`{"id":3,"type":"SHORTCUT","contents":{"shortcutData":[{"label":"Plan","order":0,"hyperlink":{"interactionType":"SMARTSHEET_ITEM","sheetId":3,"folderId":0,"pinned":true},"iconId":"sheet"}]},"height":1,"width":1,"xPosition":0,"yPosition":0}`,
+		`{"id":4,"type":"SHORTCUTLIST","contents":{"shortcutData":[{"label":"A","order":0},{"label":"B","order":1,"labelFormat":""}]},"height":2,"width":2,"xPosition":1,"yPosition":0}`,
+		`{"id":5,"type":"SHORTCUTICON","contents":{"shortcutData":[{"label":"Site","order":0,"hyperlink":{"url":"https://example.com"},"mimeType":""}]},"height":1,"width":1,"xPosition":2,"yPosition":0}`,
+		`{"id":6,"type":"METRIC","contents":{"sheetId":3,"cellData":[{"columnId":4,"rowId":5,"label":"Open","order":0,"dataSource":"CELL","cell":{"columnId":4,"value":0,"displayValue":"0","conditionalFormat":",,1","strict":false}}],"columns":[{"id":4,"title":"Count","type":"TEXT_NUMBER","index":0,"primary":false,"newColumnAttribute":true}]},"height":2,"width":2,"xPosition":0,"yPosition":2}`,
+		`{"id":7,"type":"SHEETSUMMARY","contents":{"sheetId":3,"cellData":[{"label":"Owner","order":0,"objectValue":{"objectType":"CONTACT","email":"a@b.c","name":"A"},"profileField":{"id":9}}]},"height":1,"width":2,"xPosition":2,"yPosition":2}`,
+		`{"id":8,"type":"CHART","contents":{"sheetId":3,"includedColumnIds":[4],"axes":[{"location":"LEFT","title":"Count","scale":{"min":0}}],"series":[{"title":"Open","seriesType":"BAR","color":"#FF0000"}],"legend":{"location":"RIGHT","wrap":true},"selectionRanges":[{"sourceColumnId1":4,"sourceColumnId2":4,"sourceRowId1":5,"sourceRowId2":9}],"colorPalette":"BRIGHT"},"height":4,"width":6,"xPosition":0,"yPosition":3}`,
+		`{"id":9,"type":"GRIDGANTT","contents":{"reportId":6,"htmlContent":"<table></table>","hyperlink":{"interactionType":"DISTINCT_OBJECT","reportId":6}},"height":6,"width":12,"xPosition":0,"yPosition":7}`,
+		`{"id":10,"type":"IMAGE","contents":{"privateId":"abc","fileName":"logo.png","format":"","height":0,"width":20,"altText":"Logo"},"height":2,"width":2,"xPosition":10,"yPosition":0}`,
+		`{"id":11,"type":"WEBCONTENT","contents":{"url":"https://example.com/embed","sandbox":true},"height":4,"width":4,"xPosition":8,"yPosition":3}`,
+		`{"id":12,"type":"FUTURE","contents":{"anything":[1,{"nested":"yes"}]},"height":1,"width":1,"xPosition":0,"yPosition":0}`,
+	} {
+		var widget Widget
+		assert.NoError(t, json.Unmarshal([]byte(data), &widget))
+		assert.NotNil(t, widget.Contents)
+		// json.Marshal compacts and escapes the result, so MarshalJSON is called to compare bytes
+		out, err := widget.MarshalJSON()
+		assert.NoError(t, err)
+		assert.Equal(t, data, string(out))
+	}
+}
+
+func TestWidget_MarshalChanges(t *testing.T) {
+	data := `{"id":6,"type":"METRIC","contents":{"sheetId":3,"cellData":[{"columnId":4,"rowId":5,"label":"Open","order":0,"dataSource":"CELL","cell":{"columnId":4,"value":0,"conditionalFormat":",,1"}},{"label":"Done","order":1}],"columns":[{"id":4,"title":"Count","newColumnAttribute":true}]},"height":2,"width":2,"xPosition":0,"yPosition":2}`
+	var widget Widget
+	assert.NoError(t, json.Unmarshal([]byte(data), &widget))
+	metric := widget.Contents.(*MetricContent)
+	metric.CellData[0].Label = "Still open"
+	metric.Columns[0].Title = "Total"
+	widget.XPosition = 4
+	out, err := widget.MarshalJSON()
+	assert.NoError(t, err)
+	// Changed attributes are replaced in place; unknown attributes and zero values are kept
+	assert.Equal(t, `{"id":6,"type":"METRIC","contents":{"sheetId":3,"cellData":[{"columnId":4,"rowId":5,"label":"Still open","order":0,"dataSource":"CELL","cell":{"columnId":4,"value":0,"conditionalFormat":",,1"}},{"label":"Done","order":1}],"columns":[{"id":4,"title":"Total","newColumnAttribute":true}]},"height":2,"width":2,"xPosition":4,"yPosition":2}`, string(out))
+
+	// Replacing the contents of a decoded widget sends the new contents
+	widget.Type = WidgetRichText
+	widget.Contents = &RichTextContent{HtmlContent: "text"}
+	out, err = json.Marshal(widget)
+	assert.NoError(t, err)
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(out, &fields))
+	assert.JSONEq(t, `{"htmlContent":"text"}`, string(fields["contents"]))
+}
+
+func TestWidget_MarshalReorderedItems(t *testing.T) {
+	data := `{"id":4,"type":"SHORTCUTLIST","contents":{"shortcutData":[` +
+		`{"label":"A","order":0,"shortcutId":1,"hyperlink":{"sheetId":10,"pinned":true}},` +
+		`{"label":"B","order":1,"shortcutId":2,"hyperlink":{"sheetId":20}}]},"height":2,"width":2,"xPosition":0,"yPosition":0}`
+	decode := func() (*Widget, *ShortcutContent) {
+		var widget Widget
+		assert.NoError(t, json.Unmarshal([]byte(data), &widget))
+		return &widget, widget.Contents.(*ShortcutContent)
+	}
+	// Attributes of moved or removed items are not carried over to the item now at their position
+	widget, shortcuts := decode()
+	shortcuts.ShortcutData[0], shortcuts.ShortcutData[1] = shortcuts.ShortcutData[1], shortcuts.ShortcutData[0]
+	out, err := widget.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":4,"type":"SHORTCUTLIST","contents":{"shortcutData":[`+
+		`{"hyperlink":{"sheetId":20},"label":"B","order":1},{"hyperlink":{"sheetId":10},"label":"A","order":0}]},`+
+		`"height":2,"width":2,"xPosition":0,"yPosition":0}`, string(out))
+
+	widget, shortcuts = decode()
+	shortcuts.ShortcutData = shortcuts.ShortcutData[1:]
+	out, err = widget.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `"shortcutData":[{"hyperlink":{"sheetId":20},"label":"B","order":1}]`)
+
+	// An item edited in place keeps its attributes
+	widget, shortcuts = decode()
+	shortcuts.ShortcutData[1].Label = "Budget"
+	out, err = widget.MarshalJSON()
+	assert.NoError(t, err)
+	assert.Contains(t, string(out), `{"label":"Budget","order":1,"shortcutId":2,"hyperlink":{"sheetId":20}}`)
+}
+
+func TestWidget_MarshalNew(t *testing.T) {
+	widget := Widget{
+		Type:     WidgetRichText,
+		Width:    4,
+		Height:   1,
+		Contents: &RichTextContent{HtmlContent: "<b>Overdue</b>"},
+	}
+	out, err := json.Marshal(widget)
+	assert.NoError(t, err)
+	var fields map[string]json.RawMessage
+	assert.NoError(t, json.Unmarshal(out, &fields))
+	assert.NotContains(t, fields, "id")
+	assert.JSONEq(t, `{"htmlContent":"<b>Overdue</b>"}`, string(fields["contents"]))
+}
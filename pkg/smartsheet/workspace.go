@@ -16,65 +16,161 @@
 
 package smartsheet
 
-import "time"
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
 
 type Workspace struct {
-	Id          int        `json:"id"`          // Workspace Id
-	AccessLevel string     `json:"accessLevel"` // User's permissions on the workspace
-	Favorite    bool       `json:"favorite"`    // Returned only if the user has marked the workspace as a favorite in their "Home" tab (value = true)
-	Folders     []Folder   `json:"folders"`     // Array of Folder objects
-	Name        string     `json:"name"`        // Workspace name
-	Permalink   string     `json:"permalink"`   // URL that represents a direct link to the workspace in Smartsheet
-	Reports     []Report   `json:"reports"`     // Array of Report objects
-	Sheets      []Sheet    `json:"sheets"`      // Array of Sheet objects
-	Sights      []Sight    `json:"sights"`      // Array of Sight objects
-	Templates   []Template `json:"templates"`   // Array of Template objects
+	Id          int64       `json:"id"`          // Workspace Id
+	AccessLevel AccessLevel `json:"accessLevel"` // User's permissions on the workspace
+	Favorite    bool        `json:"favorite"`    // Returned only if the user has marked the workspace as a favorite in their "Home" tab (value = true)
+	Folders     []Folder    `json:"folders"`     // Array of Folder objects
+	Name        string      `json:"name"`        // Workspace name
+	Permalink   string      `json:"permalink"`   // URL that represents a direct link to the workspace in Smartsheet
+	Reports     []Report    `json:"reports"`     // Array of Report objects
+	Sheets      []Sheet     `json:"sheets"`      // Array of Sheet objects
+	Sights      []Sight     `json:"sights"`      // Array of Sight objects
+	Templates   []Template  `json:"templates"`   // Array of Template objects
 }
 
-type Folder struct {
-	Id        int        `json:"id"`        // Folder Id
-	Favorite  bool       `json:"favorite"`  // Returned only if the user has marked the folder as a favorite in their "Home" tab (value = true)
-	Folders   []Folder   `json:"folders"`   // Array of Folder objects
-	Name      string     `json:"name"`      // Folder name
-	Permalink string     `json:"permalink"` // URL that represents a direct link to the folder in Smartsheet
-	Reports   []Report   `json:"reports"`   // Array of Report objects
-	Sheets    []Sheet    `json:"sheets"`    // Array of Sheet objects
-	Sights    []Sight    `json:"sights"`    // Array of Sight objects
-	Templates []Template `json:"templates"` // Array of Template objects
+// WorkspacePage is a page of workspaces returned by ListWorkspaces
+type WorkspacePage struct {
+	PageInfo
+	Data []Workspace `json:"data"`
 }
 
-type Report struct {
-	Scope        Scope   `json:"scope"`        // A report's scope can be defined as the sheet ids and workspace ids that make up the report.
-	SourceSheets []Sheet `json:"sourceSheets"` // Array of Sheet objects (without rows), representing the sheets that rows in the report originated from. Only included in the Get Report response if the include parameter specifies sourceSheets.
+// GetWorkspaceOptions controls what GetWorkspace returns
+type GetWorkspaceOptions struct {
+	LoadAll bool     // If true, load nested folders and their contents. Otherwise only the top level is returned
+	Include []string // Optional fields to include: ownerInfo, sheetVersion or source
 }
 
-type Sight struct {
-	Id              int       `json:"id"`              // Sight Id
-	AccessLevel     string    `json:"accessLevel"`     // User's permissions on the Sight
-	BackgroundColor string    `json:"backgroundColor"` // The hex color, for instance #E6F5FE
-	ColumnCount     int       `json:"columnCount"`     //	Number of columns that the Sight contains
-	CreatedAt       time.Time `json:"createdAt"`       //	Time of creation
-	Favorite        bool      `json:"favorite"`        //	Indicates whether the user has marked the Sight as a favorite
-	ModifiedAt      time.Time `json:"modifiedAt"`      //	Time of last modification
-	Name            string    `json:"name"`            //	Sight name
-	Permalink       string    `json:"permalink"`       //	URL that represents a direct link to the Sight in Smartsheet
-	Source          Source    `json:"source"`          //	A Source object indicating the Sight (aka dashboard) from which this Sight was created, if any
-	Widgets         []Widget  `json:"widgets"`         //	Array of Widget objects
-	Workspace       Workspace `json:"workspace"`       //	A Workspace object, limited to only 2 attributes:
+// CopyOptions controls what is copied along with a workspace or folder
+type CopyOptions struct {
+	Include   []CopyInclude // Elements to copy. Only the structure is copied if empty
+	SkipRemap []SkipRemap   // References to leave pointing at the original objects instead of their copies
 }
 
-type Widget struct {
-	Id            int         `json:"id"`            // 	Widget Id
-	Type          string      `json:"type"`          // Type of widget. See table below to see how UI widget names map to type.
-	Contents      interface{} `json:"contents"`      // object 	Data that specifies the contents of the widget. NOTE: The type of WidgetContent object (and attributes within) depends on the value of widget.type.
-	Height        int         `json:"height"`        // 	Number of rows that the widget occupies on the Sight
-	ShowTitle     bool        `json:"showTitle"`     //	True indicates that the client should display the widget title. NOTE: This is independent of the title string which may be null or empty.
-	ShowTitleIcon bool        `json:"showTitleIcon"` //	True indicates that the client should display the sheet icon in the widget title
-	Title         string      `json:"title"`         // Title of the widget
-	TitleFormat   string      `json:"titleFormat"`   // FormatDescriptor
-	Version       int         `json:"version"`       // Widget version int //
-	ViewMode      int         `json:"viewMode"`      //	1 indicates content is centered. 2 indicates content is left aligned. Must use a query parameter of level=2 to see this information.
-	Width         int         `json:"width"`         // 	Number of columns that the widget occupies on the Sight
-	XPosition     int         `json:"xPosition"`     // 	X-coordinate of widget's position on the Sight
-	YPosition     int         `json:"yPosition"`     // 	Y-coordinate of widget's position on the Sight
+// ContainerDestination is the target of a copy or move
+type ContainerDestination struct {
+	DestinationId   int64           `json:"destinationId,omitempty"`   // Id of the destination container, unless the destination is Home
+	DestinationType DestinationType `json:"destinationType,omitempty"` // folder, home or workspace
+	NewName         string          `json:"newName,omitempty"`         // Name of the copy
+}
+
+// Return WorkspacePage object
+func (c Client) ListWorkspaces(options *PageOptions) (*WorkspacePage, error) {
+	var page WorkspacePage
+	query := url.Values{}
+	options.setQuery(query)
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/workspaces", apiEndpoint), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &page); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &page, nil
+}
+
+// Return Workspace object
+func (c Client) GetWorkspace(workspaceId int64, options *GetWorkspaceOptions) (*Workspace, error) {
+	var workspace Workspace
+	query := url.Values{}
+	if options != nil {
+		if options.LoadAll {
+			query.Set("loadAll", "true")
+		}
+		if len(options.Include) > 0 {
+			query.Set("include", strings.Join(options.Include, ","))
+		}
+	}
+	resp, err := c.get(withQuery(fmt.Sprintf("%s/workspaces/%d", apiEndpoint, workspaceId), query))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &workspace); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &workspace, nil
+}
+
+// Return the created Workspace object
+func (c Client) CreateWorkspace(name string) (*Workspace, error) {
+	resp, err := c.post(fmt.Sprintf("%s/workspaces", apiEndpoint), map[string]string{"name": name}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeWorkspace(resp)
+}
+
+// Return the renamed Workspace object
+func (c Client) UpdateWorkspace(workspaceId int64, name string) (*Workspace, error) {
+	resp, err := c.put(fmt.Sprintf("%s/workspaces/%d", apiEndpoint, workspaceId), map[string]string{"name": name}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return c.decodeWorkspace(resp)
+}
+
+// Return ResultObject object
+func (c Client) DeleteWorkspace(workspaceId int64) (*ResultObject, error) {
+	var res ResultObject
+	resp, err := c.delete(fmt.Sprintf("%s/workspaces/%d", apiEndpoint, workspaceId))
+	if err != nil {
+		return nil, err
+	}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &res, nil
+}
+
+// Return the new Workspace object with its copied folders, sheets, reports, Sights and templates,
+// so the ids of the copies can be looked up. The copy is fetched with LoadAll once it is created.
+func (c Client) CopyWorkspace(workspaceId int64, newName string, options *CopyOptions) (*Workspace, error) {
+	path := withQuery(fmt.Sprintf("%s/workspaces/%d/copy", apiEndpoint, workspaceId), options.query())
+	resp, err := c.post(path, ContainerDestination{NewName: newName}, nil)
+	if err != nil {
+		return nil, err
+	}
+	copied, err := c.decodeWorkspace(resp)
+	if err != nil {
+		return nil, err
+	}
+	return c.GetWorkspace(copied.Id, &GetWorkspaceOptions{LoadAll: true})
+}
+
+func (c Client) decodeWorkspace(resp *http.Response) (*Workspace, error) {
+	var workspace Workspace
+	res := ResultObject{Result: &workspace}
+	if dErr := c.decodeJSON(resp, &res); dErr != nil {
+		return nil, fmt.Errorf("could not decode JSON response: %v", dErr)
+	}
+	return &workspace, nil
+}
+
+func (o *CopyOptions) query() url.Values {
+	query := url.Values{}
+	if o == nil {
+		return query
+	}
+	if len(o.Include) > 0 {
+		include := make([]string, len(o.Include))
+		for i := range o.Include {
+			include[i] = string(o.Include[i])
+		}
+		query.Set("include", strings.Join(include, ","))
+	}
+	if len(o.SkipRemap) > 0 {
+		skip := make([]string, len(o.SkipRemap))
+		for i := range o.SkipRemap {
+			skip[i] = string(o.SkipRemap[i])
+		}
+		query.Set("skipRemap", strings.Join(skip, ","))
+	}
+	return query
 }
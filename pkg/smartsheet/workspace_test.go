@@ -0,0 +1,106 @@
+/*
+ * Copyright 2020 wfleming@grumpysysadm.com
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package smartsheet
+
+import (
+	"encoding/json"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"testing"
+)
+
+func TestClient_ListWorkspaces(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/workspaces", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("includeAll"))
+		_, _ = w.Write([]byte(`{"pageNumber":1,"totalPages":1,"totalCount":1,"data":[{"id":7637702645442436,"name":"Golden","accessLevel":"OWNER"}]}`))
+	})
+	defer done()
+	page, err := client.ListWorkspaces(&PageOptions{IncludeAll: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []Workspace{{Id: 7637702645442436, Name: "Golden", AccessLevel: AccessLevelOwner}}, page.Data)
+}
+
+func TestClient_GetWorkspace(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/workspaces/1", r.URL.Path)
+		assert.Equal(t, "true", r.URL.Query().Get("loadAll"))
+		assert.Equal(t, "ownerInfo,source", r.URL.Query().Get("include"))
+		_, _ = w.Write([]byte(`{"id":1,"name":"Golden","folders":[{"id":2,"name":"Plans","sheets":[{"id":3,"name":"Plan"}]}],"sheets":[{"id":4,"name":"Intake"}]}`))
+	})
+	defer done()
+	workspace, err := client.GetWorkspace(1, &GetWorkspaceOptions{LoadAll: true, Include: []string{"ownerInfo", "source"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "Plan", workspace.Folders[0].Sheets[0].Name)
+	assert.Equal(t, int64(4), workspace.Sheets[0].Id)
+}
+
+func TestClient_WorkspaceCRUD(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /workspaces", "PUT /workspaces/1":
+			var body map[string]interface{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, map[string]interface{}{"name": "Client A"}, body)
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":1,"name":"Client A","accessLevel":"OWNER"}}`))
+		case "DELETE /workspaces/1":
+			_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer done()
+	created, err := client.CreateWorkspace("Client A")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), created.Id)
+	updated, err := client.UpdateWorkspace(1, "Client A")
+	assert.NoError(t, err)
+	assert.Equal(t, "Client A", updated.Name)
+	res, err := client.DeleteWorkspace(1)
+	assert.NoError(t, err)
+	assert.Equal(t, "SUCCESS", res.Message)
+}
+
+func TestClient_CopyWorkspace(t *testing.T) {
+	client, done := testServer(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			assert.Equal(t, "/workspaces/2", r.URL.Path)
+			assert.Equal(t, "true", r.URL.Query().Get("loadAll"))
+			_, _ = w.Write([]byte(`{"id":2,"name":"Client B","sheets":[{"id":20,"name":"Plan"}],"folders":[{"id":21,"name":"Archive","sheets":[{"id":22,"name":"Old"}]}]}`))
+			return
+		}
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/workspaces/1/copy", r.URL.Path)
+		assert.Equal(t, "data,cellLinks,rules", r.URL.Query().Get("include"))
+		assert.Equal(t, "reports,sights", r.URL.Query().Get("skipRemap"))
+		var destination ContainerDestination
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&destination))
+		assert.Equal(t, ContainerDestination{NewName: "Client B"}, destination)
+		_, _ = w.Write([]byte(`{"message":"SUCCESS","resultCode":0,"result":{"id":2,"name":"Client B","accessLevel":"OWNER","permalink":"https://app.smartsheet.com/w/2"}}`))
+	})
+	defer done()
+	workspace, err := client.CopyWorkspace(1, "Client B", &CopyOptions{
+		Include:   []CopyInclude{CopyIncludeData, CopyIncludeCellLinks, CopyIncludeRules},
+		SkipRemap: []SkipRemap{SkipRemapReports, SkipRemapSights},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), workspace.Id)
+	assert.Equal(t, "Client B", workspace.Name)
+	// The copied contents come with their new ids
+	assert.Equal(t, int64(20), workspace.Sheets[0].Id)
+	assert.Equal(t, int64(22), workspace.Folders[0].Sheets[0].Id)
+}